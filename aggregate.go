@@ -0,0 +1,336 @@
+// aggregate.go
+// Aggregation terminals for `range` iterators and the list
+// Created by Vokhmin D.A. 10.2026
+
+package xlist
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Number : numeric types for Sum and Average
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Reduce : combines the values with 'fn' from the first one to the last one
+// (the first value is the initial accumulator). Returns 'false' if there are no values.
+//
+// Example:
+//
+//	longest, ok := xlist.Reduce(list.Values(), func(acc, v string) string {
+//		if len(v) > len(acc) {
+//			return v
+//		}
+//		return acc
+//	})
+func Reduce[T any](seq iter.Seq[T], fn func(acc, v T) T) (T, bool) {
+	var acc T
+	first := true
+
+	for v := range seq {
+		if first {
+			acc, first = v, false
+			continue
+		}
+		acc = fn(acc, v)
+	}
+
+	return acc, !first
+}
+
+// Fold : combines the values with 'fn' starting from 'init'; the accumulator may have another type.
+//
+// Example:
+//
+//	total := xlist.Fold(orders.Values(), 0.0, func(acc float64, o Order) float64 {
+//		return acc + o.Amount
+//	})
+func Fold[T, A any](seq iter.Seq[T], init A, fn func(acc A, v T) A) A {
+	acc := init
+	for v := range seq {
+		acc = fn(acc, v)
+	}
+
+	return acc
+}
+
+// Fold2 : same as Fold, but 'fn' gets index of the value too
+func Fold2[T, A any](seq2 iter.Seq2[int, T], init A, fn func(acc A, index int, v T) A) A {
+	acc := init
+	for i, v := range seq2 {
+		acc = fn(acc, i, v)
+	}
+
+	return acc
+}
+
+// Count : returns number of the values
+func Count[T any](seq iter.Seq[T]) int {
+	n := 0
+	for range seq {
+		n++
+	}
+
+	return n
+}
+
+// CountFunc : returns number of the values for which 'is' returns true
+func CountFunc[T any](seq iter.Seq[T], is func(T) bool) int {
+	n := 0
+	for v := range seq {
+		if is(v) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// CountFunc2 : same as CountFunc, but 'is' gets index of the value too
+func CountFunc2[T any](seq2 iter.Seq2[int, T], is func(int, T) bool) int {
+	n := 0
+	for i, v := range seq2 {
+		if is(i, v) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// MinFunc : returns the first minimal value, 'less' returns true when 'a' is less than 'b'.
+// Returns 'false' if there are no values.
+func MinFunc[T any](seq iter.Seq[T], less func(a, b T) bool) (T, bool) {
+	return Reduce(seq, func(m, v T) T {
+		if less(v, m) {
+			return v
+		}
+		return m
+	})
+}
+
+// MaxFunc : returns the first maximal value, 'less' returns true when 'a' is less than 'b'.
+// Returns 'false' if there are no values.
+func MaxFunc[T any](seq iter.Seq[T], less func(a, b T) bool) (T, bool) {
+	return Reduce(seq, func(m, v T) T {
+		if less(m, v) {
+			return v
+		}
+		return m
+	})
+}
+
+// MinFunc2 : returns index and value of the first minimal value (see MinFunc), -1 if there are no values
+func MinFunc2[T any](seq2 iter.Seq2[int, T], less func(a, b T) bool) (int, T, bool) {
+	return extremum2(seq2, less)
+}
+
+// MaxFunc2 : returns index and value of the first maximal value (see MaxFunc), -1 if there are no values
+func MaxFunc2[T any](seq2 iter.Seq2[int, T], less func(a, b T) bool) (int, T, bool) {
+	return extremum2(seq2, func(a, b T) bool { return less(b, a) })
+}
+
+// extremum2 : returns index and value of the first value that no other value is 'before'
+func extremum2[T any](seq2 iter.Seq2[int, T], before func(a, b T) bool) (int, T, bool) {
+	index := -1
+	var m T
+
+	for i, v := range seq2 {
+		if index < 0 || before(v, m) {
+			index, m = i, v
+		}
+	}
+
+	return index, m, index >= 0
+}
+
+// Min : returns the minimal value, 'false' if there are no values.
+// Like the built-in min, returns NaN if any of float values is NaN.
+func Min[T cmp.Ordered](seq iter.Seq[T]) (T, bool) {
+	return Reduce(seq, func(m, v T) T { return min(m, v) })
+}
+
+// Max : returns the maximal value, 'false' if there are no values.
+// Like the built-in max, returns NaN if any of float values is NaN.
+func Max[T cmp.Ordered](seq iter.Seq[T]) (T, bool) {
+	return Reduce(seq, func(m, v T) T { return max(m, v) })
+}
+
+// Sum : returns sum of the values (0 if there are no values); overflows like the arithmetic of T
+func Sum[T Number](seq iter.Seq[T]) T {
+	var sum T
+	for v := range seq {
+		sum += v
+	}
+
+	return sum
+}
+
+// Average : returns arithmetic mean of the values, 'false' if there are no values.
+// The values are summed as float64, so the sum of small integer types does not overflow.
+func Average[T Number](seq iter.Seq[T]) (float64, bool) {
+	var sum float64
+	n := 0
+
+	for v := range seq {
+		sum += float64(v)
+		n++
+	}
+
+	if n == 0 {
+		return 0, false
+	}
+
+	return sum / float64(n), true
+}
+
+// First : returns the first value, 'false' if there are no values.
+// Only the first value is pulled from 'seq'.
+func First[T any](seq iter.Seq[T]) (T, bool) {
+	for v := range seq {
+		return v, true
+	}
+
+	var zero T
+	return zero, false
+}
+
+// First2 : returns index and value of the first pair, -1 if there are no values
+func First2[T any](seq2 iter.Seq2[int, T]) (int, T, bool) {
+	for i, v := range seq2 {
+		return i, v, true
+	}
+
+	var zero T
+	return -1, zero, false
+}
+
+// Last : returns the last value, 'false' if there are no values.
+// Pulls all values of 'seq': use list.ValuesBackward() with First to get the last value of the list.
+func Last[T any](seq iter.Seq[T]) (T, bool) {
+	var last T
+	ok := false
+
+	for v := range seq {
+		last, ok = v, true
+	}
+
+	return last, ok
+}
+
+// Last2 : returns index and value of the last pair, -1 if there are no values
+func Last2[T any](seq2 iter.Seq2[int, T]) (int, T, bool) {
+	index := -1
+	var last T
+
+	for i, v := range seq2 {
+		index, last = i, v
+	}
+
+	return index, last, index >= 0
+}
+
+// Scan : returns `range` iterator over running aggregates: 'fn' of 'init' and the first value,
+// then 'fn' of that result and the second value, and so on. 'init' itself is not yielded.
+//
+// Example:
+//
+//	for total := range xlist.Scan(list.Values(), 0, func(acc, v int) int { return acc + v }) {
+//		fmt.Println(total) // prefix sums
+//	}
+func Scan[T, A any](seq iter.Seq[T], init A, fn func(acc A, v T) A) iter.Seq[A] {
+	return func(yield func(A) bool) {
+		acc := init
+		for v := range seq {
+			acc = fn(acc, v)
+			if !yield(acc) {
+				return
+			}
+		}
+	}
+}
+
+// ----------------------------------------------------------
+// Methods of the list
+//
+// The methods walk the list under one read lock; callbacks can read the list,
+// mutations of the list inside callbacks fail with ErrReentrantCall (see WithDeferredReentrancy).
+// Aggregates that need a type parameter or a constraint (Fold, Scan, Min, Max, Sum, Average)
+// are free functions only: pass them list.Values().
+
+// Reduce : combines values of the list with 'fn' (see the free function Reduce).
+// Returns 'false' if the list is empty.
+func (p *XList[T]) Reduce(fn func(acc, v T) T) (T, bool) {
+	var (
+		acc T
+		ok  bool
+	)
+
+	p.runCallbacks(false, func() {
+		acc, ok = Reduce(p.chain(), fn)
+	})
+
+	return acc, ok
+}
+
+// CountFunc : returns number of elements for which 'is' returns true
+func (p *XList[T]) CountFunc(is func(T) bool) int {
+	var n int
+
+	p.runCallbacks(false, func() {
+		n = CountFunc(p.chain(), is)
+	})
+
+	return n
+}
+
+// MinFunc : returns the first minimal element, 'less' returns true when 'a' is less than 'b'.
+// Returns 'false' if the list is empty.
+func (p *XList[T]) MinFunc(less func(a, b T) bool) (T, bool) {
+	var (
+		m  T
+		ok bool
+	)
+
+	p.runCallbacks(false, func() {
+		m, ok = MinFunc(p.chain(), less)
+	})
+
+	return m, ok
+}
+
+// MaxFunc : returns the first maximal element, 'less' returns true when 'a' is less than 'b'.
+// Returns 'false' if the list is empty.
+func (p *XList[T]) MaxFunc(less func(a, b T) bool) (T, bool) {
+	var (
+		m  T
+		ok bool
+	)
+
+	p.runCallbacks(false, func() {
+		m, ok = MaxFunc(p.chain(), less)
+	})
+
+	return m, ok
+}
+
+// First : returns the first element, 'false' if the list is empty
+func (p *XList[T]) First() (T, bool) {
+	defer p.runlock(p.rlock())
+
+	if p.home == nil {
+		var zero T
+		return zero, false
+	}
+
+	return *p.home.obj, true
+}
+
+// Last : returns the last element, 'false' if the list is empty. Alias of LastObject, pairs with First.
+func (p *XList[T]) Last() (T, bool) {
+	return p.LastObject()
+}
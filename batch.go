@@ -0,0 +1,294 @@
+// batch.go
+// Chunking and batch processing of `range` iterators and the list
+// Created by Vokhmin D.A. 10.2026
+
+package xlist
+
+import (
+	"fmt"
+	"iter"
+	"runtime"
+	"sync"
+)
+
+// BatchError : error returned by a batch callback, wrapped with number of batches
+// processed successfully before the failed one: processing can be resumed from element Done*n.
+type BatchError struct {
+	Done int // number of batches processed successfully before the failed one
+	Err  error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("batch %d: %v", e.Done, e.Err)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// Chunk : yields consecutive chunks of 'n' values, the last chunk may be shorter.
+// Each chunk is a new slice, it can be kept. Panics with ErrInvalidRange if 'n' < 1.
+//
+// Example:
+//
+//	for chunk := range xlist.Chunk(list.Values(), 100) {
+//		db.InsertMany(chunk)
+//	}
+func Chunk[T any](seq iter.Seq[T], n int) iter.Seq[[]T] {
+	if n < 1 {
+		panic(fmt.Errorf("%w: chunk=%d", ErrInvalidRange, n))
+	}
+
+	return func(yield func([]T) bool) {
+		chunk := make([]T, 0, n)
+
+		for v := range seq {
+			chunk = append(chunk, v)
+			if len(chunk) == n {
+				if !yield(chunk) {
+					return
+				}
+				chunk = make([]T, 0, n)
+			}
+		}
+
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// ChunkBy : groups consecutive values with the same key, yields the key and the group.
+// Equal keys separated by other keys give separate groups (sort the list first to group all of them).
+//
+// Example:
+//
+//	for day, events := range xlist.ChunkBy(events.Values(), func(e Event) string { return e.Day }) {
+//		fmt.Println(day, len(events))
+//	}
+func ChunkBy[T any, K comparable](seq iter.Seq[T], key func(T) K) iter.Seq2[K, []T] {
+	return func(yield func(K, []T) bool) {
+		var (
+			group []T
+			gkey  K
+		)
+
+		for v := range seq {
+			k := key(v)
+			if len(group) > 0 && k != gkey {
+				if !yield(gkey, group) {
+					return
+				}
+				group = nil
+			}
+			gkey = k
+			group = append(group, v)
+		}
+
+		if len(group) > 0 {
+			yield(gkey, group)
+		}
+	}
+}
+
+// ForEachBatch : calls 'fn' for consecutive batches of 'n' values (the last one may be shorter).
+// Stops on the first error of 'fn' and returns it as *BatchError with number of succeeded batches.
+// Returns error ErrInvalidRange if 'n' < 1.
+func ForEachBatch[T any](seq iter.Seq[T], n int, fn func(batch []T) error) error {
+	if n < 1 {
+		return fmt.Errorf("%w: batch=%d", ErrInvalidRange, n)
+	}
+
+	done := 0
+	for batch := range Chunk(seq, n) {
+		if err := fn(batch); err != nil {
+			return &BatchError{Done: done, Err: err}
+		}
+		done++
+	}
+
+	return nil
+}
+
+// MapBatches : calls 'fn' for consecutive batches of 'n' values in 'workers' goroutines
+// (GOMAXPROCS if 'workers' < 1) and returns results in the order of the batches.
+// 'seq' is iterated by the calling goroutine.
+//
+// On an error no more batches are started; the batches in progress are finished, and
+// the results of the batches before the first failed one are returned
+// with *BatchError (Done = their number). A panic of 'fn' stops the work the same way
+// and is re-raised in the calling goroutine once the workers are done.
+// Returns error ErrInvalidRange if 'n' < 1.
+//
+// Example:
+//
+//	ids, err := xlist.MapBatches(list.Values(xlist.WithSnapshot()), 500, 4, func(batch []User) ([]int64, error) {
+//		return db.InsertUsers(batch)
+//	})
+func MapBatches[T, R any](seq iter.Seq[T], n, workers int, fn func(batch []T) (R, error)) ([]R, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("%w: batch=%d", ErrInvalidRange, n)
+	}
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	type job struct {
+		index int
+		batch []T
+	}
+
+	var (
+		mtx      sync.Mutex
+		results  []R
+		failed   = -1 // the lowest index of failed batches
+		failErr  error
+		panicked bool
+		panicVal any
+		wg       sync.WaitGroup
+	)
+
+	jobs := make(chan job)
+	stop := make(chan struct{})
+	stopped := func() bool {
+		select {
+		case <-stop:
+			return true
+		default:
+			return false
+		}
+	}
+
+	// run : calls 'fn' for the batch, a panic of 'fn' stops the work like an error (mtx is not locked).
+	// A batch taken at the moment of a failure is not started, unless it goes before the failed one.
+	run := func(j job) {
+		mtx.Lock()
+		skip := panicked || (failed >= 0 && j.index > failed)
+		mtx.Unlock()
+		if skip {
+			return
+		}
+
+		defer func() {
+			if r := recover(); r != nil {
+				mtx.Lock()
+				if !panicked {
+					panicked, panicVal = true, r
+					if failed < 0 {
+						close(stop)
+					}
+				}
+				mtx.Unlock()
+			}
+		}()
+
+		r, err := fn(j.batch)
+
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		if err != nil {
+			if failed < 0 && !panicked {
+				close(stop)
+			}
+			if failed < 0 || j.index < failed {
+				failed, failErr = j.index, err
+			}
+			return
+		}
+
+		for len(results) <= j.index {
+			var zero R
+			results = append(results, zero)
+		}
+		results[j.index] = r
+	}
+
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := range jobs {
+				run(j)
+			}
+		}()
+	}
+
+	index := 0
+dispatch:
+	for batch := range Chunk(seq, n) {
+		// checked before the send: 'select' picks a ready worker and a closed 'stop' at random
+		if stopped() {
+			break
+		}
+
+		select {
+		case jobs <- job{index: index, batch: batch}:
+			index++
+		case <-stop:
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if panicked {
+		panic(panicVal)
+	}
+
+	if failed >= 0 {
+		// all batches before 'failed' were dispatched earlier and have succeeded
+		return results[:failed:failed], &BatchError{Done: failed, Err: failErr}
+	}
+
+	return results, nil
+}
+
+// ForEachBatch : calls 'fn' for consecutive batches of 'n' elements of the list
+// (see the free function ForEachBatch). Range options select the elements and iteration mode:
+// in the default locked mode the list is read locked while 'fn' runs; use WithSnapshot
+// to release it (and to let 'fn' modify the list). Invalid range gives error ErrInvalidRange or ErrInvalidIndex.
+//
+// Example:
+//
+//	err := list.ForEachBatch(100, func(batch []Event) error {
+//		return queue.Publish(batch)
+//	}, xlist.WithSnapshot())
+//
+//	var be *xlist.BatchError
+//	if errors.As(err, &be) {
+//		retryFrom := be.Done * 100
+//	}
+func (p *XList[T]) ForEachBatch(n int, fn func(batch []T) error, opt ...func(*RangeOptions)) error {
+	if n < 1 {
+		return fmt.Errorf("%w: batch=%d", ErrInvalidRange, n)
+	}
+
+	seq, err := p.TryAll(opt...)
+	if err != nil {
+		return err
+	}
+
+	return ForEachBatch(ToValues(seq), n, fn)
+}
+
+// ForEachBatchParallel : same as ForEachBatch, but calls 'fn' in 'workers' goroutines
+// (GOMAXPROCS if 'workers' < 1); see MapBatches for handling of errors.
+// 'fn' runs in other goroutines, so the list is iterated in snapshot mode unless range options set another one.
+func (p *XList[T]) ForEachBatchParallel(n, workers int, fn func(batch []T) error, opt ...func(*RangeOptions)) error {
+	if n < 1 {
+		return fmt.Errorf("%w: batch=%d", ErrInvalidRange, n)
+	}
+
+	seq, err := p.TryAll(append([]func(*RangeOptions){WithSnapshot()}, opt...)...)
+	if err != nil {
+		return err
+	}
+
+	_, err = MapBatches(ToValues(seq), n, workers, func(batch []T) (struct{}, error) {
+		return struct{}{}, fn(batch)
+	})
+
+	return err
+}
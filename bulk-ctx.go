@@ -0,0 +1,149 @@
+// bulk-ctx.go
+// Bulk processing with cancellation and error-returning callbacks
+// Created by Vokhmin D.A. 10.2026
+
+package xlist
+
+import (
+	"context"
+	"fmt"
+)
+
+// BulkError : error that stopped a bulk operation; elements before Index were processed.
+// Err is the error of the callback for element Index, or ctx.Err() if the context was done
+// before the element.
+type BulkError struct {
+	Index int
+	Err   error
+}
+
+func (e *BulkError) Error() string {
+	return fmt.Sprintf("index %d: %v", e.Index, e.Err)
+}
+
+func (e *BulkError) Unwrap() error {
+	return e.Err
+}
+
+// WithRollback : ModifyCtx restores the modified values if it is stopped by an error or the context
+func WithRollback() func(*BulkOptions) {
+	return func(o *BulkOptions) {
+		o.rollback = true
+	}
+}
+
+// FindCtx : same as Find, but 'is' can fail and the search can be cancelled.
+// Stops on the first error of 'is' or when 'ctx' is done and returns *BulkError;
+// the list of elements found before the stop is returned too.
+// The context is checked between the callbacks: long callbacks should watch it themselves.
+func (p *XList[T]) FindCtx(ctx context.Context, is func(index int, object T) (bool, error)) (*XList[T], error) {
+	newList := newTemp[T]()
+	var err error
+
+	p.runCallbacks(false, func() {
+		err = p.walkCtx(ctx, func(index int, xobj *xlistObj[T]) error {
+			ok, err := is(index, *xobj.obj)
+			if ok && err == nil {
+				newList.append(*xobj.obj)
+			}
+			return err
+		})
+	})
+
+	return p.adopt(newList), err
+}
+
+// ModifyCtx : same as Modify, but 'change' can fail and the modification can be cancelled.
+// Stops on the first error of 'change' or when 'ctx' is done and returns *BulkError;
+// the elements before BulkError.Index stay modified, unless WithRollback option is set:
+// then they get their previous values back (the list is write locked all the time, so no one sees the partial change).
+// The context is checked between the callbacks: long callbacks should watch it themselves.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(ctx, time.Second)
+//	defer cancel()
+//
+//	err := list.ModifyCtx(ctx, func(_ int, v Doc) (Doc, error) {
+//		return userTransform(ctx, v)
+//	}, xlist.WithRollback())
+//
+//	var be *xlist.BulkError
+//	if errors.As(err, &be) {
+//		log.Printf("stopped at %d: %v", be.Index, be.Err)
+//	}
+func (p *XList[T]) ModifyCtx(ctx context.Context, change func(index int, object T) (T, error), opt ...func(*BulkOptions)) error {
+	if p.reentrant() {
+		return p.deferCall(func() error { return p.ModifyCtx(ctx, change, opt...) })
+	}
+
+	o := bulkParams(opt)
+	var err error
+
+	p.runCallbacks(true, func() {
+		var saved []T
+
+		err = p.walkCtx(ctx, func(index int, xobj *xlistObj[T]) error {
+			v, err := change(index, *xobj.obj)
+			if err != nil {
+				return err
+			}
+
+			if o.rollback {
+				saved = append(saved, *xobj.obj)
+			}
+			*xobj.obj = v
+			return nil
+		})
+
+		if err != nil && o.rollback {
+			xobj := p.home
+			for _, v := range saved {
+				*xobj.obj = v
+				xobj = xobj.next
+			}
+			return
+		}
+
+		p.valueChanged(nil)
+	})
+
+	return err
+}
+
+// ForEachCtx : calls 'fn' for each element (under read lock of the list).
+// Stops on the first error of 'fn' or when 'ctx' is done and returns *BulkError.
+// The context is checked between the callbacks: long callbacks should watch it themselves.
+func (p *XList[T]) ForEachCtx(ctx context.Context, fn func(index int, object T) error) error {
+	var err error
+
+	p.runCallbacks(false, func() {
+		err = p.walkCtx(ctx, func(index int, xobj *xlistObj[T]) error {
+			return fn(index, *xobj.obj)
+		})
+	})
+
+	return err
+}
+
+// walkCtx : calls 'fn' for each object of the list until it fails or 'ctx' is done
+// (internal: list is locked by caller)
+func (p *XList[T]) walkCtx(ctx context.Context, fn func(index int, xobj *xlistObj[T]) error) error {
+	done := ctx.Done()
+	index := 0
+
+	for xobj := p.home; xobj != nil; xobj = xobj.next {
+		select {
+		case <-done:
+			return &BulkError{Index: index, Err: ctx.Err()}
+		default:
+		}
+
+		if err := fn(index, xobj); err != nil {
+			return &BulkError{Index: index, Err: err}
+		}
+		index++
+	}
+
+	return nil
+}
@@ -6,40 +6,50 @@ package xlist
 
 // Find : looking for objects in list according to criteria defined in 'is' function and
 // returns new list with objects that were found.
+// 'is' can read the list; mutations of the list inside 'is' fail with ErrReentrantCall
+// (see WithDeferredReentrancy).
 func (p *XList[T]) Find(is func(index int, object T) bool) *XList[T] {
-	lobj := p.home
-	newList := &XList[T]{}
-	i := 0
+	newList := newTemp[T]()
 
-	p.mtx.RLock()
-	defer p.mtx.RUnlock()
+	p.runCallbacks(false, func() {
+		lobj := p.home
+		i := 0
 
-	for lobj != nil {
-		if is(i, *lobj.obj) {
-			newList.Append(*lobj.obj)
+		for lobj != nil {
+			if is(i, *lobj.obj) {
+				newList.Append(*lobj.obj)
+			}
+			lobj = lobj.next
+			i++
 		}
-		lobj = lobj.next
-		i++
-	}
+	})
 
-	return newList
+	return p.adopt(newList)
 }
 
 // Modify : modifies each element in collection with function 'change'.
 // Returns self for method chaining; return value can be ignored.
 // Supports concurrency, since each 'change' func logic performs under internal mutex.
 func (p *XList[T]) Modify(change func(index int, object T) T) *XList[T] {
-	p.mtx.Lock()
-	defer p.mtx.Unlock()
+	if p.reentrant() {
+		if err := p.deferAction(func() { p.Modify(change) }); err != nil {
+			panic(err)
+		}
+		return p
+	}
 
-	lobj := p.home
-	i := 0
+	p.runCallbacks(true, func() {
+		p.valueChanged(nil)
 
-	for lobj != nil {
-		*lobj.obj = change(i, *lobj.obj)
-		lobj = lobj.next
-		i++
-	}
+		lobj := p.home
+		i := 0
+
+		for lobj != nil {
+			*lobj.obj = change(i, *lobj.obj)
+			lobj = lobj.next
+			i++
+		}
+	})
 
 	return p
 }
@@ -47,17 +57,25 @@ func (p *XList[T]) Modify(change func(index int, object T) T) *XList[T] {
 // ModifyRev : modify each element in collection (go in reverse order)
 // Returns self for method chaining; return value can be ignored.
 func (p *XList[T]) ModifyRev(change func(index int, object T) T) *XList[T] {
-	p.mtx.Lock()
-	defer p.mtx.Unlock()
+	if p.reentrant() {
+		if err := p.deferAction(func() { p.ModifyRev(change) }); err != nil {
+			panic(err)
+		}
+		return p
+	}
 
-	lobj := p.end
-	i := p.Size() - 1
+	p.runCallbacks(true, func() {
+		p.valueChanged(nil)
 
-	for lobj != nil {
-		*lobj.obj = change(i, *lobj.obj)
-		lobj = lobj.prev
-		i--
-	}
+		lobj := p.end
+		i := p.length() - 1
+
+		for lobj != nil {
+			*lobj.obj = change(i, *lobj.obj)
+			lobj = lobj.prev
+			i--
+		}
+	})
 
 	return p
 }
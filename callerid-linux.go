@@ -0,0 +1,15 @@
+// callerid-linux.go
+// Identity of the goroutine that runs callbacks (Linux: OS thread id)
+// Created by Vokhmin D.A. 10.2026
+
+//go:build linux
+
+package xlist
+
+import "syscall"
+
+// callerID : returns id of the OS thread. The goroutine that runs callbacks is locked
+// to its thread (runtime.LockOSThread), so no other goroutine can have the same id meanwhile.
+func callerID() int64 {
+	return int64(syscall.Gettid())
+}
@@ -0,0 +1,12 @@
+// callerid-other.go
+// Identity of the goroutine that runs callbacks (other systems: goroutine id)
+// Created by Vokhmin D.A. 10.2026
+
+//go:build !linux
+
+package xlist
+
+// callerID : returns id of the current goroutine
+func callerID() int64 {
+	return goid()
+}
@@ -0,0 +1,301 @@
+// combinators.go
+// Combinators of `range` iterators: Zip, Concat, Interleave, Window, FlatMap, ...
+// Created by Vokhmin D.A. 10.2026
+
+package xlist
+
+import (
+	"fmt"
+	"iter"
+)
+
+// Note: Zip, ZipLongest and Interleave pull all their sources with iter.Pull. A pulled source
+// in locked mode (the default of All, Values, ...) copies the range of its list under the read lock
+// and yields the copy (see Pull), so a list can be zipped with itself, and the loop body may modify it.
+
+// Zipped : pair of values of ZipLongest; Ok1/Ok2 is false when the source is exhausted
+// (the value is zero then)
+type Zipped[A, B any] struct {
+	V1  A
+	Ok1 bool
+
+	V2  B
+	Ok2 bool
+}
+
+// Zip : walks 'a' and 'b' in lockstep, yields pairs of their values; stops when any of them is exhausted.
+//
+// Example:
+//
+//	for name, score := range xlist.Zip(names.Values(), scores.Values()) {
+//		fmt.Println(name, score)
+//	}
+func Zip[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		nextA, stopA := pull(a)
+		defer stopA()
+		nextB, stopB := pull(b)
+		defer stopB()
+
+		for {
+			va, ok := nextA()
+			if !ok {
+				return
+			}
+			vb, ok := nextB()
+			if !ok || !yield(va, vb) {
+				return
+			}
+		}
+	}
+}
+
+// ZipLongest : walks 'a' and 'b' in lockstep until both of them are exhausted;
+// the missing values of the shorter one are marked in Zipped.
+func ZipLongest[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq[Zipped[A, B]] {
+	return func(yield func(Zipped[A, B]) bool) {
+		nextA, stopA := pull(a)
+		defer stopA()
+		nextB, stopB := pull(b)
+		defer stopB()
+
+		for {
+			var z Zipped[A, B]
+			z.V1, z.Ok1 = nextA()
+			z.V2, z.Ok2 = nextB()
+
+			if (!z.Ok1 && !z.Ok2) || !yield(z) {
+				return
+			}
+		}
+	}
+}
+
+// Concat : yields values of 'seqs' one after another
+//
+// Example:
+//
+//	for v := range xlist.Concat(list1.Values(), list2.ValuesBackward()) {
+//		fmt.Println(v)
+//	}
+func Concat[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, seq := range seqs {
+			for v := range seq {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Concat2 : yields pairs of 'seqs' one after another; indexes of All/Backward stay as they are
+func Concat2[K, V any](seqs ...iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, seq := range seqs {
+			for k, v := range seq {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Interleave : yields one value of each of 'seqs' in turn (round-robin);
+// exhausted sequences drop out, the rest go on.
+//
+// Example:
+//
+//	xlist.Interleave(xlist.New(1, 2, 3).Values(), xlist.New(10, 20).Values()) // 1, 10, 2, 20, 3
+func Interleave[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		nexts := make([]func() (T, bool), 0, len(seqs))
+		for _, seq := range seqs {
+			next, stop := pull(seq)
+			defer stop()
+			nexts = append(nexts, next)
+		}
+
+		for len(nexts) > 0 {
+			alive := nexts[:0]
+			for _, next := range nexts {
+				v, ok := next()
+				if !ok {
+					continue
+				}
+				if !yield(v) {
+					return
+				}
+				alive = append(alive, next)
+			}
+			nexts = alive
+		}
+	}
+}
+
+// Enumerate : yields values of 'seq' with their sequence numbers from 0
+// (Filter, TakeWhile, ... need an index)
+func Enumerate[T any](seq iter.Seq[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for v := range seq {
+			if !yield(i, v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Pairwise : yields adjacent pairs of values: (v0, v1), (v1, v2), ...; nothing if there are less than 2 values.
+//
+// Example:
+//
+//	for prev, cur := range xlist.Pairwise(samples.Values()) {
+//		fmt.Println(cur - prev)
+//	}
+func Pairwise[T any](seq iter.Seq[T]) iter.Seq2[T, T] {
+	return func(yield func(T, T) bool) {
+		var prev T
+		first := true
+
+		for v := range seq {
+			if first {
+				prev, first = v, false
+				continue
+			}
+			if !yield(prev, v) {
+				return
+			}
+			prev = v
+		}
+	}
+}
+
+// Window : yields sliding windows of 'n' adjacent values: [v0..vn-1], [v1..vn], ...;
+// nothing if there are less than 'n' values. Each window is a new slice, it can be kept.
+// Panics with ErrInvalidRange if 'n' < 1.
+func Window[T any](seq iter.Seq[T], n int) iter.Seq[[]T] {
+	if n < 1 {
+		panic(fmt.Errorf("%w: window=%d", ErrInvalidRange, n))
+	}
+
+	return func(yield func([]T) bool) {
+		buf := make([]T, 0, n)
+
+		for v := range seq {
+			if len(buf) == n {
+				buf = append(buf[:0], buf[1:]...)
+			}
+			buf = append(buf, v)
+
+			if len(buf) == n && !yield(append([]T(nil), buf...)) {
+				return
+			}
+		}
+	}
+}
+
+// FlatMap : yields values of the sequences returned by 'fn' for each value of 'seq'
+//
+// Example:
+//
+//	tags := xlist.FlatMap(posts.Values(), func(p Post) iter.Seq[string] {
+//		return slices.Values(p.Tags)
+//	})
+func FlatMap[T, V any](seq iter.Seq[T], fn func(T) iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for v := range seq {
+			for r := range fn(v) {
+				if !yield(r) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Flatten : yields values of each sequence of 'seqs' one after another
+func Flatten[T any](seqs iter.Seq[iter.Seq[T]]) iter.Seq[T] {
+	return FlatMap(seqs, func(seq iter.Seq[T]) iter.Seq[T] { return seq })
+}
+
+// FlattenLists : yields values of each list of 'lists' one after another; 'opt' is applied
+// to each list (nil lists are skipped). Lists are iterated one by one: in locked mode
+// only the current list is read locked.
+//
+// Example:
+//
+//	for v := range xlist.FlattenLists(groups.Values()) { // groups *XList[*XList[int]]
+//		fmt.Println(v)
+//	}
+func FlattenLists[T comparable](lists iter.Seq[*XList[T]], opt ...func(*RangeOptions)) iter.Seq[T] {
+	return FlatMap(lists, func(list *XList[T]) iter.Seq[T] {
+		if list == nil {
+			return func(func(T) bool) {}
+		}
+		return list.Values(opt...)
+	})
+}
+
+// Cycle : yields values of 'seq' again and again, until the loop breaks.
+// 'seq' is ranged anew for each pass, so it must be reusable (All, Values, ... are);
+// the cycle stops when a pass yields nothing.
+func Cycle[T any](seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			empty := true
+			for v := range seq {
+				empty = false
+				if !yield(v) {
+					return
+				}
+			}
+			if empty {
+				return
+			}
+		}
+	}
+}
+
+// Repeat : yields 'v' 'n' times, endlessly if 'n' < 0
+func Repeat[T any](v T, n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; n < 0 || i < n; i++ {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Map2 : transforms values of pairs, keeps the keys (indexes of All/Backward, first values of Zip, ...)
+//
+// Example:
+//
+//	for i, s := range xlist.Map2(list.All(), func(i int, v int) string { return fmt.Sprint(v) }) {
+//		fmt.Println(i, s)
+//	}
+func Map2[K, V, R any](seq2 iter.Seq2[K, V], transform func(K, V) R) iter.Seq2[K, R] {
+	return func(yield func(K, R) bool) {
+		for k, v := range seq2 {
+			if !yield(k, transform(k, v)) {
+				return
+			}
+		}
+	}
+}
+
+// Filter2 : passes through pairs for which 'is' returns true. Same as Filter,
+// but for pairs of any types (Zip, Pairwise, Map2, ...).
+func Filter2[K, V any](seq2 iter.Seq2[K, V], is func(K, V) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range seq2 {
+			if is(k, v) && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
@@ -4,11 +4,6 @@
 
 package xlist
 
-import (
-	"crypto/sha256"
-	"encoding/json"
-)
-
 // ------ Core functions ------
 
 // At : returns the value at the specified index.
@@ -16,8 +11,7 @@ import (
 // This method is recommended for value types (e.g., XList[int], XList[string])
 // where you need to distinguish between a valid zero value and a missing element.
 func (p *XList[T]) At(index int) (T, bool) {
-	p.mtx.RLock()
-	defer p.mtx.RUnlock()
+	defer p.runlock(p.rlock())
 
 	return p.at(index)
 }
@@ -45,8 +39,7 @@ func (p *XList[T]) AtPtr(index int) T {
 
 // IsEmpty : returns 'true' if container is empty
 func (p *XList[T]) IsEmpty() bool {
-	p.mtx.RLock()
-	defer p.mtx.RUnlock()
+	defer p.runlock(p.rlock())
 
 	if p.home == nil && p.end == nil {
 		return true
@@ -66,7 +59,12 @@ func (p *XList[T]) isEmpty() bool {
 
 // Size : returns size of container.
 func (p *XList[T]) Size() int {
-	return p.size
+	return p.length()
+}
+
+// length : returns size of container (for internal use, atomic)
+func (p *XList[T]) length() int {
+	return int(p.size.Load())
 }
 
 // LastObject returns the last element in the container.
@@ -74,7 +72,9 @@ func (p *XList[T]) Size() int {
 // This method is recommended for value types (e.g., XList[int], XList[string])
 // where you need to distinguish between a valid zero value and an empty container.
 func (p *XList[T]) LastObject() (T, bool) {
-	return p.At(p.size - 1)
+	defer p.runlock(p.rlock())
+
+	return p.at(p.length() - 1)
 }
 
 // LastObjectPtr returns the last element in the container, or zero value if container is empty.
@@ -88,26 +88,51 @@ func (p *XList[T]) LastObjectPtr() T {
 
 // Clear : clear container.
 func (p *XList[T]) Clear() *XList[T] {
-	p.mtx.Lock()
-	defer p.mtx.Unlock()
+	if p.reentrant() {
+		if err := p.deferAction(func() { p.Clear() }); err != nil {
+			panic(err)
+		}
+		return p
+	}
+
+	p.lock()
+	defer p.unlock()
+
+	p.clear()
 
+	return p
+}
+
+// clear : clear container (for internal use without mutex)
+func (p *XList[T]) clear() {
 	p.home = nil
 	p.end = nil
-	p.size = 0
+	p.size.Store(0)
+	p.modCount++
 
-	return p
+	p.notifyCleared()
 }
 
 // Set : set 'objects' to container.
 // In case of empty objects receiver will be unchanged.
 // Returns self for method chaining; return value can be ignored.
 func (p *XList[T]) Set(objects ...T) *XList[T] {
+	if p.reentrant() {
+		if err := p.deferAction(func() { p.Set(objects...) }); err != nil {
+			panic(err)
+		}
+		return p
+	}
+
 	if len(objects) == 0 {
 		return p
 	}
 
-	p.Clear()
-	p.Append(objects...)
+	p.lock()
+	defer p.unlock()
+
+	p.clear()
+	p.append(objects...)
 
 	return p
 }
@@ -116,76 +141,40 @@ func (p *XList[T]) Set(objects ...T) *XList[T] {
 // In case of empty objects receiver will be unchanged.
 // Returns self for method chaining; return value can be ignored.
 func (p *XList[T]) Append(objects ...T) *XList[T] {
-	p.mtx.Lock()
-	defer p.mtx.Unlock()
-
-	for _, obj := range objects {
-		lobj := &xlistObj[T]{
-			obj: &obj,
+	if p.reentrant() {
+		if err := p.deferAction(func() { p.Append(objects...) }); err != nil {
+			panic(err)
 		}
+		return p
+	}
 
-		p.size++
-
-		if p.isEmpty() {
-			p.home = lobj
-			p.end = p.home
+	p.lock()
+	defer p.unlock()
 
-			continue
-		}
+	p.append(objects...)
 
-		lobj.prev = p.end
-		p.end.next = lobj
-		p.end = lobj
+	return p
+}
 
+// append : appends 'objects' to container (for internal use without mutex)
+func (p *XList[T]) append(objects ...T) {
+	for _, obj := range objects {
+		p.linkBefore(nil, &xlistObj[T]{obj: &obj})
 	}
-
-	return p
 }
 
-// AppendUnique : appends element if it doesn't exist in current collection.
+// AppendUnique : appends objects that don't exist in the list (and skips repeated ones among 'objects').
+// Elements are compared with == or, if T implements Hasher[T], by Hash and Equal (see unique.go).
 // Returns self for method chaining; return value can be ignored.
 func (p *XList[T]) AppendUnique(objects ...T) *XList[T] {
-	var hash [32]byte
-	isObj := make(map[any]bool)
-
-	getHash := func(obj *T) [32]byte {
-		if obj == nil {
-			return [32]byte{}
-		}
-
-		data, err := json.Marshal(*obj)
-		if err != nil {
+	if p.reentrant() {
+		if err := p.deferAction(func() { p.AppendUnique(objects...) }); err != nil {
 			panic(err)
 		}
-
-		hash = sha256.Sum256(data)
-
-		return hash
-	}
-
-	// Create hash map
-	lobj := p.home
-	p.mtx.RLock()
-	for lobj != nil {
-		hash = getHash(lobj.obj)
-		isObj[hash] = true
-
-		lobj = lobj.next
-	}
-	p.mtx.RUnlock()
-
-	if len(isObj) == 0 {
-		p.Append(objects...)
 		return p
 	}
 
-	// Check object for uniqueness and add it
-	for _, obj := range objects {
-		hash = getHash(&obj)
-		if _, found := isObj[hash]; !found {
-			p.Append(obj)
-		}
-	}
+	p.appendUnique(newSeenSet[T](), objects)
 
 	return p
 }
@@ -211,6 +200,8 @@ func (p *XList[T]) containsInternal(containsSome bool, objects ...T) bool { // n
 		return true // The empty set is a subset of every set.
 	}
 
+	defer p.runlock(p.rlock())
+
 	if p.home == nil {
 		return false
 	}
@@ -218,8 +209,6 @@ func (p *XList[T]) containsInternal(containsSome bool, objects ...T) bool { // n
 	// Special case for one object
 	if len(objects) == 1 {
 		target := objects[0]
-		p.mtx.RLock()
-		defer p.mtx.RUnlock()
 
 		lobj := p.home
 		for lobj != nil {
@@ -236,9 +225,6 @@ func (p *XList[T]) containsInternal(containsSome bool, objects ...T) bool { // n
 		lookingFor[obj] = struct{}{}
 	}
 
-	p.mtx.RLock()
-	defer p.mtx.RUnlock()
-
 	xobj := p.home
 	for xobj != nil {
 		if _, found := lookingFor[*xobj.obj]; found {
@@ -260,58 +246,32 @@ func (p *XList[T]) containsInternal(containsSome bool, objects ...T) bool { // n
 // Insert : inserts object before the 'pos' position
 // if position is out of right range, append element - no error
 func (p *XList[T]) Insert(pos int, objects ...T) error {
-	if pos < 0 || pos > p.size {
+	if p.reentrant() {
+		return p.deferCall(func() error { return p.Insert(pos, objects...) })
+	}
+
+	p.lock()
+	defer p.unlock()
+
+	if pos < 0 || pos > p.length() {
 		return ErrInvalidIndex
 	}
 
 	// insert last element
-	if p.size == pos {
-		p.Append(objects...)
+	if p.length() == pos {
+		p.append(objects...)
 		return nil
 	}
 
-	p.mtx.Lock()
-	defer p.mtx.Unlock()
+	// go to insert position
+	xobj := p.goToPosition(pos)
+	if xobj == nil {
+		return ErrInvalidIndex
+	}
 
+	// all objects go before the same element
 	for _, obj := range objects {
-		lobj := &xlistObj[T]{
-			obj: &obj,
-		}
-
-		// insert first element, position ignores
-		xobj := p.home
-		if xobj == nil { // for empty
-			if pos != 0 {
-				return ErrInvalidIndex
-			}
-
-			p.home = lobj
-			p.end = lobj
-
-			p.size = 1
-			pos++
-			continue
-		}
-
-		// go to insert position
-		xobj = p.goToPosition(pos)
-		if xobj == nil {
-			return ErrInvalidIndex
-		}
-
-		// insert new element
-		lobj.next = xobj
-		lobj.prev = xobj.prev
-
-		if xobj.prev != nil {
-			xobj.prev.next = lobj
-		} else {
-			p.home = lobj // put object at 0 pos
-		}
-
-		xobj.prev = lobj
-		p.size++
-		pos++ // move position for multiple insert
+		p.linkBefore(xobj, &xlistObj[T]{obj: &obj})
 	}
 
 	return nil
@@ -320,8 +280,12 @@ func (p *XList[T]) Insert(pos int, objects ...T) error {
 // Replace : replaces element at position 'pos' to 'obj'.
 // Returns 'true' if replaced, 'false' if not
 func (p *XList[T]) Replace(pos int, obj T) error {
-	p.mtx.Lock()
-	defer p.mtx.Unlock()
+	if p.reentrant() {
+		return p.deferCall(func() error { return p.Replace(pos, obj) })
+	}
+
+	p.lock()
+	defer p.unlock()
 
 	if p.isEmpty() {
 		return ErrElementNotFound
@@ -332,135 +296,136 @@ func (p *XList[T]) Replace(pos int, obj T) error {
 		return ErrElementNotFound
 	}
 	xobj.obj = &obj
+	p.valueChanged(xobj)
 
 	return nil
 }
 
 // ReplaceLast : replaces last element, returns 'true' if replaced, 'false' if not.
 func (p *XList[T]) ReplaceLast(obj T) error {
-	p.mtx.Lock()
-	defer p.mtx.Unlock()
+	if p.reentrant() {
+		return p.deferCall(func() error { return p.ReplaceLast(obj) })
+	}
+
+	p.lock()
+	defer p.unlock()
 
 	if p.isEmpty() {
 		return ErrElementNotFound
 	}
 
-	xobj := p.goToPosition(p.size - 1)
+	xobj := p.end
 	if xobj == nil {
 		return ErrElementNotFound
 	}
 	xobj.obj = &obj
+	p.valueChanged(xobj)
 
 	return nil
 }
 
 // DeleteAt : deletes and returns the element at the specified position, or an error if the position is invalid.
 func (p *XList[T]) DeleteAt(pos int) (T, error) {
-	var zero T
+	if p.reentrant() {
+		var zero T
+		return zero, p.deferCall(func() error { _, err := p.DeleteAt(pos); return err })
+	}
 
-	p.mtx.Lock()
-	defer p.mtx.Unlock()
+	p.lock()
+	defer p.unlock()
+
+	return p.deleteAt(pos)
+}
+
+// deleteAt : deletes element at position 'pos' (for internal use without mutex)
+func (p *XList[T]) deleteAt(pos int) (T, error) {
+	var zero T
 
 	if p.isEmpty() {
 		return zero, nil
 	}
 
-	if pos < 0 || pos >= p.size {
+	if pos < 0 || pos >= p.length() {
 		return zero, ErrInvalidIndex
 	}
 
 	xobj := p.goToPosition(pos)
 	if xobj == nil {
-		var zero T
 		return zero, ErrElementNotFound
 	}
 
-	if xobj.prev != nil {
-		xobj.prev.next = xobj.next
-	}
-
-	if xobj.next != nil {
-		xobj.next.prev = xobj.prev
-	}
-
-	// First element
-	if p.home == xobj {
-		p.home = xobj.next
-	}
-
-	// Last element
-	if p.end == xobj {
-		p.end = xobj.prev
-	}
-
-	p.size--
+	p.unlink(xobj)
 
 	return *xobj.obj, nil
 }
 
+// DeleteLast : deletes and returns the last element, or an error if the container is empty.
 func (p *XList[T]) DeleteLast() (T, error) {
-	p.mtx.RLock()
-	if p.end == nil {
+	if p.reentrant() {
 		var zero T
-		p.mtx.RUnlock()
+		return zero, p.deferCall(func() error { _, err := p.DeleteLast(); return err })
+	}
+
+	p.lock()
+	defer p.unlock()
 
+	if p.end == nil {
+		var zero T
 		return zero, ErrElementNotFound
 	}
-	p.mtx.RUnlock()
 
-	return p.DeleteAt(p.Size() - 1)
+	return p.deleteAt(p.length() - 1)
 }
 
 // AppendList  adds objects to the end of the list (mutating).
+// Elements of 'dList' are copied (shallow), 'dList' stays unchanged.
 // Returns self for method chaining; return value can be ignored.
 // (-) Add
 func (p *XList[T]) AppendList(dList *XList[T]) *XList[T] {
-	p.mtx.RLock()
-	defer p.mtx.RUnlock()
-
-	if dList.isEmpty() && p.isEmpty() {
-		return &XList[T]{}
+	if p.reentrant() {
+		if err := p.deferAction(func() { p.AppendList(dList) }); err != nil {
+			panic(err)
+		}
+		return p
 	}
 
-	targetCp := p
-
-	if dList.isEmpty() {
-		return targetCp
+	if dList == nil {
+		return p
 	}
 
+	// Copy source first: it takes the read lock of 'dList' (which may be the receiver itself)
 	sourceCp := dList.Copy()
-
-	if p.isEmpty() {
-		return sourceCp
-	}
-
-	// Connect 2 chains
-	if targetCp.end != nil {
-		targetCp.end.next = sourceCp.home
-	}
-
-	if sourceCp.home != nil {
-		sourceCp.home.prev = targetCp.end
+	if sourceCp.isEmpty() {
+		return p
 	}
 
-	// Set the targetCp.end to the tail of connected chain
-	targetCp.end = sourceCp.end
+	p.lock()
+	defer p.unlock()
 
-	targetCp.size += sourceCp.size
+	p.spliceTail(sourceCp)
 
-	return targetCp
+	return p
 }
 
 // Splice : move content from 'dList' to receiver at its tail (appends container - mutating).
 // (!) 'dList' is destroyed, it becomes empty.
 // (-) Move
 func (p *XList[T]) Splice(dList *XList[T]) *XList[T] {
+	if p.reentrant() {
+		if err := p.deferAction(func() { p.Splice(dList) }); err != nil {
+			panic(err)
+		}
+		return p
+	}
 
-	if dList == nil || dList.isEmpty() {
+	if dList == nil || dList == p {
 		return p
 	}
 
-	_ = p.SpliceAtPos(p.size, dList)
+	unlock := lockPair(p, dList)
+	defer unlock()
+
+	p.spliceTail(dList)
 
 	return p
 }
@@ -469,50 +434,28 @@ func (p *XList[T]) Splice(dList *XList[T]) *XList[T] {
 // (!) 'dList' is destroyed, it becomes empty.
 // (-) MoveAtPos
 func (p *XList[T]) SpliceAtPos(pos int, dList *XList[T]) error {
-	p.mtx.Lock()
-	defer p.mtx.Unlock()
-
-	if dList.isEmpty() {
-		return nil
+	if p.reentrant() {
+		return p.deferCall(func() error { return p.SpliceAtPos(pos, dList) })
 	}
 
-	if pos < 0 || pos > p.size {
-		return ErrInvalidIndex
-	}
-
-	resetSrc := func(dList *XList[T]) {
-		dList.home = nil
-		dList.end = nil
-		dList.size = 0
+	if dList == nil || dList == p {
+		return nil
 	}
 
-	// In case of empty receiver
-	if p.isEmpty() {
-		if pos != 0 {
-			return ErrInvalidIndex
-		}
-		p.home = dList.home
-		p.end = dList.end
-		p.size = dList.size
-		resetSrc(dList)
+	unlock := lockPair(p, dList)
+	defer unlock()
 
+	if dList.isEmpty() {
 		return nil
 	}
 
-	// Connect chain to the tail
-	if pos == p.size {
-		if p.end != nil {
-			p.end.next = dList.home
-		}
-		if dList.home != nil {
-			dList.home.prev = p.end
-		}
-
-		p.end = dList.end
-		p.size += dList.size
-
-		resetSrc(dList)
+	if pos < 0 || pos > p.length() {
+		return ErrInvalidIndex
+	}
 
+	// Connect chain to the tail (or to empty receiver)
+	if pos == p.length() {
+		p.spliceTail(dList)
 		return nil
 	}
 
@@ -529,34 +472,55 @@ func (p *XList[T]) SpliceAtPos(pos int, dList *XList[T]) error {
 		p.home = dList.home
 	}
 
-	if dList.home != nil {
-		dList.home.prev = xobj.prev
-	}
+	dList.home.prev = xobj.prev
 
 	// right side of dList
-	//dList.end = xobj
 	dList.end.next = xobj
 	xobj.prev = dList.end
 
+	p.size.Add(dList.size.Load())
+	p.modCount++
+	p.notifyLinkedChain(dList.home, dList.end)
+
 	// Reset dList
-	resetSrc(dList)
+	dList.clear()
 
 	return nil
 }
 
+// spliceTail : moves chain of 'dList' to the tail of receiver, 'dList' becomes empty.
+// Both lists must be locked by caller.
+func (p *XList[T]) spliceTail(dList *XList[T]) {
+	if dList.isEmpty() {
+		return
+	}
+
+	if p.isEmpty() {
+		p.home = dList.home
+	} else {
+		p.end.next = dList.home
+		dList.home.prev = p.end
+	}
+
+	p.end = dList.end
+	p.size.Add(dList.size.Load())
+	p.modCount++
+	p.notifyLinkedChain(dList.home, dList.end)
+
+	dList.clear()
+}
+
 // Copy : returns a copy of the list.
 // It makes shallow copies of objects, so be careful when changing container objects.
 // Consider 'DeepCopy' method to copy the container objects themselves.
 func (p *XList[T]) Copy() *XList[T] {
-	if p.isEmpty() {
-		return &XList[T]{}
-	}
-	na, _ := p.CopyRange(0, p.size-1)
+	na, _ := p.CopyRange(0, -1)
 	return na
 }
 
 // CopyRange : returns a new container with elements of receiver for specified range [fromPos, toPos].
 // It makes shallow copies of objects, so be careful when changing container objects .
+// 'toPos' = -1 means the last element (the range is resolved under the lock).
 func (p *XList[T]) CopyRange(fromPos int, toPos int) (*XList[T], error) {
 	return p.DeepCopyRange(fromPos, toPos, func(obj T) T {
 		return obj
@@ -566,26 +530,44 @@ func (p *XList[T]) CopyRange(fromPos int, toPos int) (*XList[T], error) {
 // DeepCopy :  returns a new container with new elements of receiver.
 // It makes deep copies of objects, so you must provide a closure 'deepCopyFn' to make a deep copy of type T.
 func (p *XList[T]) DeepCopy(deepCopyFn func(T) T) *XList[T] {
-	if p.isEmpty() || deepCopyFn == nil {
-		return &XList[T]{}
+	if deepCopyFn == nil {
+		return p.adopt(newTemp[T]())
 	}
 
-	na, _ := p.DeepCopyRange(0, p.size-1, deepCopyFn)
+	na, _ := p.DeepCopyRange(0, -1, deepCopyFn)
 	return na
 }
 
 // DeepCopyRange : returns a new container with elements from the range [fromPos, toPos].
 // You must provide a closure 'deepCopyFn' that knows how to make a deep copy of type T.
+// 'toPos' = -1 means the last element; a whole range of empty container gives an empty container.
 func (p *XList[T]) DeepCopyRange(fromPos int, toPos int, deepCopyFn func(T) T) (*XList[T], error) {
 
 	if deepCopyFn == nil {
 		return nil, ErrNoClosure
 	}
 
-	p.mtx.RLock()
-	defer p.mtx.RUnlock()
+	var result *XList[T]
+	var err error
 
-	if fromPos < 0 || fromPos > p.size-1 || toPos < 0 || toPos > p.size-1 || fromPos > toPos {
+	p.runCallbacks(false, func() {
+		result, err = p.deepCopyRange(fromPos, toPos, deepCopyFn)
+	})
+
+	return result, err
+}
+
+// deepCopyRange : internal realisation of DeepCopyRange (the list is locked by caller)
+func (p *XList[T]) deepCopyRange(fromPos int, toPos int, deepCopyFn func(T) T) (*XList[T], error) {
+	size := p.length()
+	if toPos == -1 {
+		if fromPos == 0 && size == 0 {
+			return p.adopt(newTemp[T]()), nil
+		}
+		toPos = size - 1
+	}
+
+	if fromPos < 0 || fromPos > size-1 || toPos < 0 || toPos > size-1 || fromPos > toPos {
 		return nil, ErrInvalidIndex
 	}
 
@@ -595,7 +577,7 @@ func (p *XList[T]) DeepCopyRange(fromPos int, toPos int, deepCopyFn func(T) T) (
 		return nil, ErrElementNotFound
 	}
 
-	result := &XList[T]{}
+	result := newTemp[T]()
 	xobj := xobjs[0]
 	i := fromPos
 
@@ -609,15 +591,19 @@ func (p *XList[T]) DeepCopyRange(fromPos int, toPos int, deepCopyFn func(T) T) (
 		xobj = xobj.next
 		i++
 	}
-	return result, nil
+	return p.adopt(result), nil
 }
 
 // Swap : swapping 2 elements in the list.
 func (p *XList[T]) Swap(i, j int) error {
-	p.mtx.Lock()
-	defer p.mtx.Unlock()
+	if p.reentrant() {
+		return p.deferCall(func() error { return p.Swap(i, j) })
+	}
+
+	p.lock()
+	defer p.unlock()
 
-	if i < 0 || j < 0 || i > p.size-1 || j > p.size-1 {
+	if i < 0 || j < 0 || i > p.length()-1 || j > p.length()-1 {
 		return ErrInvalidIndex
 	}
 
@@ -642,5 +628,7 @@ func (p *XList[T]) swap(i, j int) {
 
 	if objI != nil && objJ != nil {
 		objI.obj, objJ.obj = objJ.obj, objI.obj
+		p.valueChanged(objI)
+		p.valueChanged(objJ)
 	}
 }
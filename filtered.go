@@ -0,0 +1,365 @@
+// filtered.go
+// Live read-only filtered views of the list
+// Created by Vokhmin D.A. 10.2026
+
+package xlist
+
+import (
+	"iter"
+	"sync"
+)
+
+// WhereOptions : options of filtered views (see XList.Where)
+type WhereOptions struct {
+	indexed bool
+}
+
+// WithMembershipIndex : the filtered view keeps a set of matching elements, updated by changes of the list.
+// 'pred' is called only for new and changed elements, so Size and Contains are O(1);
+// At and All still walk the list in order, but don't call 'pred'.
+// Changes made through pointers (AtPtr, LastObjectPtr) are not seen by the index.
+func WithMembershipIndex() func(*WhereOptions) {
+	return func(o *WhereOptions) {
+		o.indexed = true
+	}
+}
+
+// Filtered : live read-only view of elements of the list that satisfy a predicate (see XList.Where).
+// Reads reflect the current contents of the list and run under its read lock;
+// 'pred' runs as a callback of the list (it can read the list, but must not use the view).
+type Filtered[T comparable] struct {
+	list *XList[T]
+	pred func(T) bool
+
+	idx *memberIndex[T] // nil - no membership index, each read calls 'pred' for the elements it walks
+}
+
+// Where : returns live filtered view of elements for which 'pred' returns true.
+// Without options each read calls 'pred' for the elements it walks; WithMembershipIndex makes reads cheap.
+// A view with index subscribes to changes of the list: Close it when it is not needed anymore.
+//
+// Example:
+//
+//	active := list.Where(func(u *User) bool { return u.Active }, xlist.WithMembershipIndex())
+//	defer active.Close()
+//
+//	fmt.Println(active.Size())
+//	for i, u := range active.All() {
+//		fmt.Println(i, u.Name)
+//	}
+func (p *XList[T]) Where(pred func(T) bool, opt ...func(*WhereOptions)) *Filtered[T] {
+	o := WhereOptions{}
+	for _, optSet := range opt {
+		optSet(&o)
+	}
+
+	f := &Filtered[T]{list: p, pred: pred}
+	if !o.indexed {
+		return f
+	}
+
+	f.idx = &memberIndex[T]{
+		pred:    pred,
+		members: make(map[*xlistObj[T]]T),
+		counts:  make(map[T]int),
+		dirty:   make(map[*xlistObj[T]]struct{}),
+		stale:   true, // built at the first read
+	}
+
+	if p.reentrant() {
+		panic(ErrReentrantCall)
+	}
+
+	p.lock()
+	defer p.unlock()
+
+	p.observe(f.idx)
+
+	return f
+}
+
+// Close : unsubscribes the membership index from changes of the list (no-op without index).
+// The view works without the index after that.
+func (f *Filtered[T]) Close() {
+	p := f.list
+	if p.reentrant() {
+		if err := p.deferAction(f.Close); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	p.lock()
+	defer p.unlock()
+
+	if f.idx != nil {
+		p.unobserve(f.idx)
+		f.idx = nil
+	}
+}
+
+// Size : returns number of matching elements
+func (f *Filtered[T]) Size() int {
+	n := 0
+
+	f.list.runCallbacks(false, func() {
+		if idx := f.index(); idx != nil {
+			n = idx.size()
+			return
+		}
+
+		for range f.objects(nil) {
+			n++
+		}
+	})
+
+	return n
+}
+
+// At : returns matching element number 'pos' (in order of the list)
+func (f *Filtered[T]) At(pos int) (T, bool) {
+	var (
+		value T
+		ok    bool
+	)
+
+	if pos < 0 {
+		return value, false
+	}
+
+	f.list.runCallbacks(false, func() {
+		i := 0
+		for xobj := range f.objects(f.index()) {
+			if i == pos {
+				value, ok = *xobj.obj, true
+				return
+			}
+			i++
+		}
+	})
+
+	return value, ok
+}
+
+// Contains : checks whether all 'objects' are matching elements of the list
+func (f *Filtered[T]) Contains(objects ...T) bool {
+	found := true
+
+	f.list.runCallbacks(false, func() {
+		if idx := f.index(); idx != nil {
+			found = idx.contains(objects)
+			return
+		}
+
+		lookingFor := make(map[T]struct{}, len(objects))
+		for _, obj := range objects {
+			if !f.pred(obj) {
+				found = false
+				return
+			}
+			lookingFor[obj] = struct{}{}
+		}
+
+		for v := range f.list.chain() {
+			delete(lookingFor, v)
+			if len(lookingFor) == 0 {
+				return
+			}
+		}
+		found = len(lookingFor) == 0
+	})
+
+	return found
+}
+
+// All : returns `range` iterator over (number among matching elements, value);
+// the list is read locked during the loop (like in the locked mode of XList.All)
+func (f *Filtered[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		f.list.runLoop(yield, func(yield func(int, T) bool) {
+			i := 0
+			for xobj := range f.objects(f.index()) {
+				if !yield(i, *xobj.obj) {
+					return
+				}
+				i++
+			}
+		})
+	}
+}
+
+// Values : returns `range` iterator over matching values (see All)
+func (f *Filtered[T]) Values() iter.Seq[T] {
+	return ToValues(f.All())
+}
+
+// Slice : returns copy of matching values
+func (f *Filtered[T]) Slice() []T {
+	var values []T
+
+	f.list.runCallbacks(false, func() {
+		for xobj := range f.objects(f.index()) {
+			values = append(values, *xobj.obj)
+		}
+	})
+
+	return values
+}
+
+// index : returns membership index brought up to date, nil if the view has no index
+// (internal: list is read locked by caller)
+func (f *Filtered[T]) index() *memberIndex[T] {
+	idx := f.idx
+	if idx == nil {
+		return nil
+	}
+
+	idx.sync(f.list)
+
+	return idx
+}
+
+// objects : `range` iterator over matching objects, by index 'idx' or by 'pred' if it's nil
+// (internal: list is read locked by caller)
+func (f *Filtered[T]) objects(idx *memberIndex[T]) iter.Seq[*xlistObj[T]] {
+	return func(yield func(*xlistObj[T]) bool) {
+		for xobj := f.list.home; xobj != nil; xobj = xobj.next {
+			var ok bool
+			if idx != nil {
+				ok = idx.has(xobj)
+			} else {
+				ok = f.pred(*xobj.obj)
+			}
+
+			if ok && !yield(xobj) {
+				return
+			}
+		}
+	}
+}
+
+// ----------------------------------------------------------
+
+// memberIndex : set of matching objects of the list.
+// Observer hooks only record changed objects, 'pred' is called by the next read (in a callback scope of the list).
+type memberIndex[T comparable] struct {
+	mtx sync.Mutex // reads run concurrently under read lock of the list
+
+	pred    func(T) bool
+	members map[*xlistObj[T]]T        // matching objects and their values at the check
+	counts  map[T]int                 // number of matching objects by value
+	dirty   map[*xlistObj[T]]struct{} // linked or changed objects to check
+	stale   bool                      // all objects have to be checked
+}
+
+// sync : checks the recorded objects (internal: list is read locked by caller)
+func (m *memberIndex[T]) sync(p *XList[T]) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.stale {
+		m.stale = false
+		clear(m.members)
+		clear(m.counts)
+		clear(m.dirty)
+
+		for xobj := p.home; xobj != nil; xobj = xobj.next {
+			m.check(xobj)
+		}
+		return
+	}
+
+	for xobj := range m.dirty {
+		m.drop(xobj)
+		m.check(xobj)
+	}
+	clear(m.dirty)
+}
+
+// has : returns 'true' if 'xobj' is a member
+func (m *memberIndex[T]) has(xobj *xlistObj[T]) bool {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	_, ok := m.members[xobj]
+	return ok
+}
+
+// size : returns number of members
+func (m *memberIndex[T]) size() int {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return len(m.members)
+}
+
+// contains : checks whether all 'objects' are values of members
+func (m *memberIndex[T]) contains(objects []T) bool {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for _, obj := range objects {
+		if m.counts[obj] == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// check : adds 'xobj' to members if it matches (mtx is locked by caller)
+func (m *memberIndex[T]) check(xobj *xlistObj[T]) {
+	if v := *xobj.obj; m.pred(v) {
+		m.members[xobj] = v
+		m.counts[v]++
+	}
+}
+
+// drop : removes 'xobj' from members (mtx is locked by caller)
+func (m *memberIndex[T]) drop(xobj *xlistObj[T]) {
+	v, ok := m.members[xobj]
+	if !ok {
+		return
+	}
+
+	delete(m.members, xobj)
+	if m.counts[v]--; m.counts[v] == 0 {
+		delete(m.counts, v)
+	}
+}
+
+func (m *memberIndex[T]) linked(xobj *xlistObj[T]) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.dirty[xobj] = struct{}{}
+}
+
+func (m *memberIndex[T]) unlinked(xobj *xlistObj[T]) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.dirty, xobj)
+	m.drop(xobj)
+}
+
+func (m *memberIndex[T]) cleared() {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	clear(m.members)
+	clear(m.counts)
+	clear(m.dirty)
+	m.stale = false
+}
+
+func (m *memberIndex[T]) changed(xobj *xlistObj[T]) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if xobj == nil {
+		m.stale = true
+		return
+	}
+	m.dirty[xobj] = struct{}{}
+}
@@ -6,14 +6,14 @@ package xlist
 
 // Slice : get all collection objects as a slice
 func (p *XList[T]) Slice() []T {
-	result := make([]T, 0, p.size)
+	defer p.runlock(p.rlock())
 
-	p.mtx.RLock()
-	defer p.mtx.RUnlock()
+	result := make([]T, 0, p.length())
 
 	xobj := p.home
 	for xobj != nil {
 		result = append(result, *xobj.obj)
+		xobj = xobj.next
 	}
 
 	return result
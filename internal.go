@@ -4,14 +4,84 @@
 
 package xlist
 
-import "sort"
+import (
+	"iter"
+	"runtime"
+	"sort"
+)
 
 //  ----------------
 
+// linkBefore : links new object 'lobj' into chain before 'xobj' ('xobj' = nil - to the tail)
+func (p *XList[T]) linkBefore(xobj, lobj *xlistObj[T]) {
+	if xobj == nil {
+		lobj.prev = p.end
+		lobj.next = nil
+
+		if p.end != nil {
+			p.end.next = lobj
+		} else {
+			p.home = lobj
+		}
+		p.end = lobj
+	} else {
+		lobj.next = xobj
+		lobj.prev = xobj.prev
+
+		if xobj.prev != nil {
+			xobj.prev.next = lobj
+		} else {
+			p.home = lobj // put object at 0 pos
+		}
+		xobj.prev = lobj
+	}
+
+	p.size.Add(1)
+	p.modCount++
+
+	p.notifyLinked(lobj)
+}
+
+// unlink : removes object 'xobj' from chain.
+// The object keeps links to its former neighbours and is marked as removed.
+func (p *XList[T]) unlink(xobj *xlistObj[T]) {
+	if xobj.prev != nil {
+		xobj.prev.next = xobj.next
+	} else {
+		p.home = xobj.next // First element
+	}
+
+	if xobj.next != nil {
+		xobj.next.prev = xobj.prev
+	} else {
+		p.end = xobj.prev // Last element
+	}
+
+	xobj.removed = true
+
+	p.size.Add(-1)
+	p.modCount++
+
+	p.notifyUnlinked(xobj)
+}
+
+// indexOfObj : returns index of object 'xobj' in chain or -1 if it isn't there
+func (p *XList[T]) indexOfObj(xobj *xlistObj[T]) int {
+	i := 0
+	for lobj := p.home; lobj != nil; lobj = lobj.next {
+		if lobj == xobj {
+			return i
+		}
+		i++
+	}
+
+	return -1
+}
+
 // goToPosition : go to object at 'pos' position
 // returns internal 'xlistObj' struct
 func (p *XList[T]) goToPosition(pos int) *xlistObj[T] {
-	if pos < 0 || pos > p.size-1 {
+	if pos < 0 || pos > p.length()-1 {
 		return nil
 	}
 
@@ -50,7 +120,7 @@ func (p *XList[T]) getObjectsAt(pos ...int) []*xlistObj[T] {
 
 	for xobj != nil {
 		position := pos[ip]
-		if position < 0 || position > p.size-1 { // in case of position outside the range
+		if position < 0 || position > p.length()-1 { // in case of position outside the range
 			ip++
 			if ip >= lenpos {
 				break
@@ -72,3 +142,31 @@ func (p *XList[T]) getObjectsAt(pos ...int) []*xlistObj[T] {
 
 	return objects
 }
+
+// goid : returns id of the current goroutine (parsed from the stack header "goroutine N [...").
+func goid() int64 {
+	var buf [32]byte
+
+	n := runtime.Stack(buf[:], false)
+
+	var id int64
+	for _, c := range buf[len("goroutine "):n] {
+		if c < '0' || c > '9' {
+			break
+		}
+		id = id*10 + int64(c-'0')
+	}
+
+	return id
+}
+
+// chain : `range` iterator over values of the list without locking (internal: list is locked by caller)
+func (p *XList[T]) chain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for xobj := p.home; xobj != nil; xobj = xobj.next {
+			if !yield(*xobj.obj) {
+				return
+			}
+		}
+	}
+}
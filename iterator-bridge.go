@@ -0,0 +1,348 @@
+// iterator-bridge.go
+// Bridge between Iterator and `range` iterators (iter.Seq, iter.Pull)
+// Created by Vokhmin D.A. 10.2026
+
+package xlist
+
+import (
+	"iter"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Seq : returns `range` iterator over the elements after the current position of the Iterator
+// (from the first one of the work range if the Iterator is not positioned).
+// The Iterator moves with the loop: after 'break' it stays on the last yielded element,
+// so the next Seq (or Next) resumes from there.
+// The list is read locked for each step only: the loop body may edit the list through the Iterator
+// (Remove, Set, InsertAfter, ...); other modifications stop the loop (see Err).
+//
+// Example:
+//
+//	it := list.Iterator()
+//	for v := range it.Seq() {
+//		if v == stop {
+//			break
+//		}
+//	}
+//	for v := range it.Seq() { // resumes after 'stop'
+//		fmt.Println(v)
+//	}
+func (p *Iterator[T]) Seq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			_, value, ok := p.step()
+			if !ok || !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Seq2 : same as Seq, but yields (index, value), so the Iterator can drive Filter, TakeWhile, SkipWhile, ...
+//
+// Example:
+//
+//	for i, v := range xlist.Filter(it.Seq2(), isValid) {
+//		fmt.Println(i, v)
+//	}
+func (p *Iterator[T]) Seq2() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for {
+			index, value, ok := p.step()
+			if !ok || !yield(index, value) {
+				return
+			}
+		}
+	}
+}
+
+// step : moves the Iterator to the next element, returns its index and value
+func (p *Iterator[T]) step() (int, T, bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	defer p.parent.runlock(p.parent.rlock())
+
+	if !p.next() {
+		var zero T
+		return -1, zero, false
+	}
+
+	return p.index, *p.lobj.obj, true
+}
+
+// ----------------------------------------------------------
+
+// PullIterator : stateful forward iterator over pull functions of iter.Pull/iter.Pull2
+// (see FromPull, FromPull2, Pull, Pull2). Works like Iterator: Next, Value, Index, NextValue.
+// Stop must be called if the iterator is not exhausted, to release the source.
+type PullIterator[T any] struct {
+	mtx sync.Mutex // protects iterator state and serializes calls of 'next' and 'stop'
+
+	next func() (int, T, bool)
+	stop func()
+
+	index int
+	value T
+	valid bool // current value is set
+	done  bool // source is exhausted or stopped
+}
+
+// FromPull : creates PullIterator from 'next' and 'stop' functions returned by iter.Pull.
+// Index counts the pulled values from 0.
+func FromPull[T any](next func() (T, bool), stop func()) *PullIterator[T] {
+	index := -1
+
+	return FromPull2(func() (int, T, bool) {
+		value, ok := next()
+		if ok {
+			index++
+		}
+		return index, value, ok
+	}, stop)
+}
+
+// FromPull2 : creates PullIterator from 'next' and 'stop' functions returned by iter.Pull2.
+// Index is taken from the source.
+func FromPull2[T any](next func() (int, T, bool), stop func()) *PullIterator[T] {
+	return &PullIterator[T]{next: pullNext2(next), stop: stop, index: -1}
+}
+
+// Pull : converts `range` iterator to PullIterator, so the loop can be paused and resumed.
+//
+// Note: a pulled iterator of the list in locked mode (default) copies its range under the read lock
+// at the first Next and yields the copy (like WithSnapshot), so the list is not locked while the
+// iterator is paused and the goroutine that pulls may modify it. Use WithLive to see the changes.
+// AllMut and BackwardMut can't be pulled (panic with ErrPulledLoop).
+//
+// Example:
+//
+//	it := xlist.Pull(list.Values())
+//	defer it.Stop()
+//
+//	first, _ := it.NextValue()
+//	list.Append(first) // doesn't wait for Stop
+func Pull[T any](seq iter.Seq[T]) *PullIterator[T] {
+	return FromPull(iter.Pull(seq))
+}
+
+// Pull2 : converts `range` iterator with indexes (list.All, list.Backward, ...) to PullIterator.
+// See notes of Pull.
+func Pull2[T any](seq iter.Seq2[int, T]) *PullIterator[T] {
+	return FromPull2(iter.Pull2(seq))
+}
+
+// Next : moves the iterator to the next value, returns 'false' when the source is exhausted.
+func (p *PullIterator[T]) Next() bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	return p.pull()
+}
+
+// Value : returns current value.
+func (p *PullIterator[T]) Value() (T, bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	return p.value, p.valid
+}
+
+// Index : returns current index, -1 if there is no current value.
+func (p *PullIterator[T]) Index() int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if !p.valid {
+		return -1
+	}
+	return p.index
+}
+
+// NextValue : moves the iterator to the next value and returns it,
+// 'false' when the source is exhausted.
+func (p *PullIterator[T]) NextValue() (T, bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.pull()
+
+	return p.value, p.valid
+}
+
+// Stop : stops the source; the iterator is exhausted then. Can be called several times.
+func (p *PullIterator[T]) Stop() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.finish()
+}
+
+// Seq : returns `range` iterator over the rest of values; the PullIterator moves with the loop.
+func (p *PullIterator[T]) Seq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			value, ok := p.NextValue()
+			if !ok || !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Seq2 : returns `range` iterator over the rest of (index, value) pairs; the PullIterator moves with the loop.
+func (p *PullIterator[T]) Seq2() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for {
+			index, value, ok := p.step()
+			if !ok || !yield(index, value) {
+				return
+			}
+		}
+	}
+}
+
+// step : moves the iterator to the next value, returns its index and value
+func (p *PullIterator[T]) step() (int, T, bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if !p.pull() {
+		return -1, p.value, false
+	}
+
+	return p.index, p.value, true
+}
+
+// pull : takes the next value from the source (internal: iterator is locked by caller)
+func (p *PullIterator[T]) pull() bool {
+	if p.done {
+		return false
+	}
+
+	index, value, ok := p.next()
+	if !ok {
+		p.finish()
+		return false
+	}
+
+	p.index, p.value, p.valid = index, value, true
+
+	return true
+}
+
+// finish : stops the source and clears current value (internal: iterator is locked by caller)
+func (p *PullIterator[T]) finish() {
+	if !p.done {
+		p.done = true
+		p.stop()
+	}
+
+	var zero T
+	p.value, p.valid = zero, false
+}
+
+// ----------------------------------------------------------
+
+// Loops of the list have to know whether they are pulled with iter.Pull (see runLoop):
+//   - the yield of a loop pulled directly is the one of iter.Pull/iter.Pull2 (or of ToValues over it),
+//     told by the function name;
+//   - a loop wrapped by other iterators (Map, Filter, a stream, ...) sees the yield of the wrapper.
+//     PullIterator, Zip, ZipLongest and Interleave count their calls of 'next' in 'pulling',
+//     the loops started meanwhile look for iter.Pull among their callers (slow, but only then).
+//
+// Wrapped loops pulled by 'next' of iter.Pull called directly are not detected:
+// use WithSnapshot or WithLive for them.
+
+// pulling : number of running calls of 'next' functions wrapped by pullNext
+var pulling atomic.Int32
+
+// pullFuncs : code address -> it belongs to iter.Pull/iter.Pull2 (or to the yield of pulledValues)
+var pullFuncs sync.Map
+
+// pulledValuesName : name of the yield made by pulledValues, the same for all types
+var pulledValuesName = funcName(reflect.ValueOf(pulledValues[int](nil)).Pointer())
+
+// pulledValues : makes yield of (index, value) for the loop under ToValues pulled with 'yield'
+// (not inlined: the name of the closure tells that the loop is pulled)
+//
+//go:noinline
+func pulledValues[T any](yield func(T) bool) func(int, T) bool {
+	return func(_ int, value T) bool {
+		return yield(value)
+	}
+}
+
+// pull : same as iter.Pull with 'next' counted in 'pulling'
+func pull[T any](seq iter.Seq[T]) (func() (T, bool), func()) {
+	next, stop := iter.Pull(seq)
+
+	return pullNext(next), stop
+}
+
+// pullNext : wraps 'next' of iter.Pull, its calls are counted in 'pulling'
+func pullNext[T any](next func() (T, bool)) func() (T, bool) {
+	return func() (T, bool) {
+		pulling.Add(1)
+		defer pulling.Add(-1)
+
+		return next()
+	}
+}
+
+// pullNext2 : same as pullNext for 'next' of iter.Pull2
+func pullNext2[K, V any](next func() (K, V, bool)) func() (K, V, bool) {
+	return func() (K, V, bool) {
+		pulling.Add(1)
+		defer pulling.Add(-1)
+
+		return next()
+	}
+}
+
+// pulled : reports whether the loop with 'yield' runs in a coroutine of iter.Pull (see above)
+func pulled(yield any) bool {
+	if pullFunc(reflect.ValueOf(yield).Pointer()) {
+		return true
+	}
+
+	if pulling.Load() == 0 {
+		return false
+	}
+
+	var pcs [64]uintptr
+	n := runtime.Callers(2, pcs[:])
+	for _, pc := range pcs[:n] {
+		if pullFunc(pc - 1) { // return address -> call instruction
+			return true
+		}
+	}
+
+	return false
+}
+
+// pullFunc : reports whether code address 'pc' belongs to iter.Pull/iter.Pull2 or to the yield of pulledValues
+func pullFunc(pc uintptr) bool {
+	if is, ok := pullFuncs.Load(pc); ok {
+		return is.(bool)
+	}
+
+	name := funcName(pc)
+	is := strings.HasPrefix(name, "iter.Pull[") || strings.HasPrefix(name, "iter.Pull2[") || name == pulledValuesName
+	pullFuncs.Store(pc, is)
+
+	return is
+}
+
+// funcName : returns name of the function at code address 'pc', "" if it's unknown
+func funcName(pc uintptr) string {
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return ""
+	}
+
+	return fn.Name()
+}
@@ -0,0 +1,96 @@
+// iterator-edit.go
+// Editing of the list through the Iterator
+// Created by Vokhmin D.A. 10.2026
+
+package xlist
+
+// Edits go through the current node of the iterator, so each of them is O(1).
+// The iterator keeps its work range and index correct after an edit, and stays valid:
+// its own edits don't trigger ErrConcurrentModification.
+//
+// Errors:
+//   - ErrElementNotFound: the iterator is not positioned or its element is removed;
+//   - ErrConcurrentModification: the list was modified by someone else (see Err);
+//   - ErrReentrantCall: called from a callback of the same list.
+
+// Remove : deletes the current element.
+// The iterator stays between the neighbours of the removed element: Next moves to the element
+// that followed it (at the same index), Prev - to the element before it. Value returns 'false' until then.
+func (p *Iterator[T]) Remove() error {
+	return p.edit(func() {
+		p.parent.unlink(p.lobj)
+		p.finish--
+	})
+}
+
+// Set : replaces value of the current element.
+func (p *Iterator[T]) Set(obj T) error {
+	return p.edit(func() {
+		p.lobj.obj = &obj
+		p.parent.valueChanged(p.lobj)
+	})
+}
+
+// InsertBefore : inserts 'objects' before the current element.
+// The iterator stays on the current element (its index grows), the work range grows with inserted elements.
+func (p *Iterator[T]) InsertBefore(objects ...T) error {
+	return p.edit(func() {
+		for _, obj := range objects {
+			p.parent.linkBefore(p.lobj, &xlistObj[T]{obj: &obj})
+		}
+		p.index += len(objects)
+		p.finish += len(objects)
+	})
+}
+
+// InsertAfter : inserts 'objects' after the current element, Next visits them.
+// The work range grows with inserted elements.
+func (p *Iterator[T]) InsertAfter(objects ...T) error {
+	return p.edit(func() {
+		next := p.lobj.next
+		for _, obj := range objects {
+			p.parent.linkBefore(next, &xlistObj[T]{obj: &obj})
+		}
+		p.finish += len(objects)
+	})
+}
+
+// Mark : marks the current element.
+func (p *Iterator[T]) Mark() error {
+	return p.edit(func() {
+		p.lobj.mark = true
+	})
+}
+
+// Unmark : clears mark of the current element.
+func (p *Iterator[T]) Unmark() error {
+	return p.edit(func() {
+		p.lobj.mark = false
+	})
+}
+
+// edit : runs 'fn' on the current element under write lock of the parent,
+// then takes the modification counters of the parent as seen by the iterator.
+func (p *Iterator[T]) edit(fn func()) error {
+	if p.parent.reentrant() {
+		return ErrReentrantCall
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.parent.lock()
+	defer p.parent.unlock()
+
+	if !p.valid() {
+		return p.err
+	}
+
+	if p.lobj == nil || p.lobj.removed {
+		return ErrElementNotFound
+	}
+
+	fn()
+	p.capture()
+
+	return nil
+}
@@ -27,10 +27,14 @@ func (p *XList[T]) Iterator(workRange ...int) *Iterator[T] {
 // Reset - resets the iterator with a new range of work.
 // If empty, the iterator is reset to pass from the first to the last of the container elements.
 func (p *Iterator[T]) Reset(workRange ...int) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
 	p.lobj = nil
 	p.index = -1
 	p.start = -1
 	p.finish = -1
+	p.err = nil
 
 	switch len(workRange) {
 	case 1:
@@ -45,25 +49,77 @@ func (p *Iterator[T]) Reset(workRange ...int) {
 	}
 }
 
+// setInitial : resolves the default work range (internal: iterator and parent are locked by caller)
 func (p *Iterator[T]) setInitial() {
 	if p.start == -1 {
 		p.start = 0
 	}
 
 	if p.finish == -1 {
-		p.finish = p.parent.Size() - 1
+		p.finish = p.parent.length() - 1
+	}
+}
+
+// AllowValueChanges : the iterator tolerates value modifications of the list
+// (Replace, ReplaceLast, Swap, Modify, Sort); structural modifications still invalidate it.
+// Returns self for method chaining.
+func (p *Iterator[T]) AllowValueChanges() *Iterator[T] {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.allowValues = true
+
+	return p
+}
+
+// Err : returns ErrConcurrentModification if the list was modified during iteration
+// (not by the iterator itself), nil otherwise. The iterator stays invalid until Reset.
+func (p *Iterator[T]) Err() error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	return p.err
+}
+
+// capture : remembers modification counters of the parent (internal: iterator and parent are locked by caller)
+func (p *Iterator[T]) capture() {
+	p.modCount = p.parent.modCount
+	p.valCount = p.parent.valCount
+}
+
+// valid : checks that the parent was not modified since the iterator was positioned,
+// otherwise invalidates the iterator (internal: iterator and parent are locked by caller)
+func (p *Iterator[T]) valid() bool {
+	if p.err != nil {
+		return false
+	}
+
+	if p.lobj == nil { // not positioned yet
+		return true
 	}
+
+	if p.parent.modifiedSince(p.modCount, p.valCount, p.allowValues) {
+		p.err = ErrConcurrentModification
+		p.lobj = nil
+		return false
+	}
+
+	return true
 }
+
 func (p *Iterator[T]) setInitialForward() {
 	p.setInitial()
+	p.capture()
 
 	p.lobj = p.parent.goToPosition(p.start)
 	if p.lobj != nil {
 		p.index = p.start
 	}
 }
+
 func (p *Iterator[T]) setInitialBackward() {
 	p.setInitial()
+	p.capture()
 
 	p.lobj = p.parent.goToPosition(p.finish)
 	if p.lobj != nil {
@@ -73,16 +129,26 @@ func (p *Iterator[T]) setInitialBackward() {
 
 // SetIndex : sets the iterator to the specified index.
 func (p *Iterator[T]) SetIndex(index int) (T, bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	defer p.parent.runlock(p.parent.rlock())
+
+	var zero T
+
+	if !p.valid() {
+		return zero, false
+	}
+
 	p.setInitial()
 
 	xObj := p.parent.goToPosition(index)
-	if xObj == nil || (index < p.start || index > p.finish) || index > p.parent.Size()-1 {
-		var zero T
+	if xObj == nil || (index < p.start || index > p.finish) || index > p.parent.length()-1 {
 		return zero, false
 	}
 
 	p.lobj = xObj
 	p.index = index
+	p.capture()
 
 	return *xObj.obj, true
 }
@@ -90,7 +156,16 @@ func (p *Iterator[T]) SetIndex(index int) (T, bool) {
 // SetFirst : returns the first element of the container.
 // If Iterator was initialized with range, then returns the first element of the range.
 func (p *Iterator[T]) SetFirst() (T, bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	defer p.parent.runlock(p.parent.rlock())
+
 	var xObj *xlistObj[T]
+	var zero T
+
+	if !p.valid() {
+		return zero, false
+	}
 
 	p.setInitial()
 
@@ -101,12 +176,12 @@ func (p *Iterator[T]) SetFirst() (T, bool) {
 	}
 
 	if xObj == nil {
-		var zero T
 		return zero, false
 	}
 
 	p.lobj = xObj
 	p.index = p.start
+	p.capture()
 
 	return *xObj.obj, true
 }
@@ -114,30 +189,43 @@ func (p *Iterator[T]) SetFirst() (T, bool) {
 // SetLast : returns the last element of the container.
 // If Iterator was initialized with range, then returns the last element of the range.
 func (p *Iterator[T]) SetLast() (T, bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	defer p.parent.runlock(p.parent.rlock())
+
 	var xObj *xlistObj[T]
+	var zero T
+
+	if !p.valid() {
+		return zero, false
+	}
 
 	p.setInitial()
 
-	if p.finish == p.parent.Size()-1 {
+	if p.finish == p.parent.length()-1 {
 		xObj = p.parent.end
 	} else {
 		xObj = p.parent.goToPosition(p.finish)
 	}
 
 	if xObj == nil {
-		var zero T
 		return zero, false
 	}
 
 	p.lobj = xObj
 	p.index = p.finish
+	p.capture()
 
 	return *xObj.obj, true
 }
 
-// Index : returns current index.
+// Index : returns current index, -1 if the iterator is not positioned or invalid.
 func (p *Iterator[T]) Index() int {
-	if p.lobj == nil {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	defer p.parent.runlock(p.parent.rlock())
+
+	if !p.valid() || p.lobj == nil {
 		return -1
 	}
 	return p.index
@@ -145,7 +233,11 @@ func (p *Iterator[T]) Index() int {
 
 // Value : returns current iterator value.
 func (p *Iterator[T]) Value() (T, bool) {
-	if p.lobj == nil {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	defer p.parent.runlock(p.parent.rlock())
+
+	if !p.valid() || p.lobj == nil || p.lobj.removed {
 		var zero T
 		return zero, false
 	}
@@ -153,13 +245,37 @@ func (p *Iterator[T]) Value() (T, bool) {
 	return *p.lobj.obj, true
 }
 
+// Next : moves the iterator to the next element, returns 'false' at the end of the work range.
 func (p *Iterator[T]) Next() bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	defer p.parent.runlock(p.parent.rlock())
+
+	return p.next()
+}
+
+// next : Next implementation (internal: iterator and parent are locked by caller)
+func (p *Iterator[T]) next() bool {
+	if !p.valid() {
+		return false
+	}
+
 	if p.lobj == nil {
 		p.setInitialForward()
 
 		return p.lobj != nil
 	}
 
+	if p.lobj.removed { // current element was removed by the iterator, the next one took its index
+		if p.lobj.next == nil || p.index > p.finish {
+			return false
+		}
+
+		p.lobj = p.lobj.next
+
+		return true
+	}
+
 	if p.lobj.next == nil || p.index >= p.finish {
 		return false
 	}
@@ -170,7 +286,21 @@ func (p *Iterator[T]) Next() bool {
 	return true
 }
 
+// Prev : moves the iterator to the previous element, returns 'false' at the begin of the work range.
 func (p *Iterator[T]) Prev() bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	defer p.parent.runlock(p.parent.rlock())
+
+	return p.prev()
+}
+
+// prev : Prev implementation (internal: iterator and parent are locked by caller)
+func (p *Iterator[T]) prev() bool {
+	if !p.valid() {
+		return false
+	}
+
 	if p.lobj == nil {
 		p.setInitialBackward()
 
@@ -190,8 +320,16 @@ func (p *Iterator[T]) Prev() bool {
 // NextValue : returns next value from container and 'true' if value is valid,
 // 'false' in case of the list end value is reached (invalid value).
 func (p *Iterator[T]) NextValue() (T, bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	defer p.parent.runlock(p.parent.rlock())
+
 	var zero T // empty object
 
+	if !p.valid() {
+		return zero, false
+	}
+
 	if p.lobj == nil {
 		p.setInitialForward()
 
@@ -203,6 +341,16 @@ func (p *Iterator[T]) NextValue() (T, bool) {
 		return *p.lobj.obj, p.lobj != nil
 	}
 
+	if p.lobj.removed { // current element was removed by the iterator, the next one took its index
+		if p.lobj.next == nil || p.index > p.finish {
+			return zero, false
+		}
+
+		p.lobj = p.lobj.next
+
+		return *p.lobj.obj, true
+	}
+
 	if p.lobj == nil || (p.index+1 > p.finish && p.index > 0) {
 		return zero, false
 	}
@@ -220,10 +368,19 @@ func (p *Iterator[T]) NextValue() (T, bool) {
 // PrevValue : returns previous value from container and 'true' if value is valid,
 // 'false' in case of the list begin value is reached (invalid value).
 func (p *Iterator[T]) PrevValue() (T, bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	defer p.parent.runlock(p.parent.rlock())
+
 	var zero T
 
+	if !p.valid() {
+		return zero, false
+	}
+
 	if p.lobj == nil {
 		p.lobj = p.parent.end
+		p.capture()
 	}
 
 	if p.lobj == nil || (p.index+1 < p.start && p.index > 0) {
@@ -0,0 +1,403 @@
+// keyindex.go
+// Secondary key indexes of the list: O(1) lookup of elements by key
+// Created by Vokhmin D.A. 10.2026
+
+package xlist
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+)
+
+// Indexes subscribe to changes of the list (see observers.go). The hooks only record the changed
+// objects; keys are calculated by the next Lookup, in a callback scope of the list, so key
+// functions follow the reentrancy rules of callbacks and cost nothing to the mutations themselves.
+// Bulk value changes (Modify, Sort, ...) make the next Lookup check keys of all objects;
+// only the objects whose keys changed are moved.
+// Objects of a key are kept in list order: the hooks give each linked object an order label
+// between the labels of its neighbours, so buckets are sorted by labels without walks of the list.
+// A unique index finds duplicates when it catches up with the list: lookups of a shared key fail
+// with ErrDuplicateKey until the duplicates are removed, other keys are not affected.
+
+// KeyIndex : typed handle of a secondary key index of the list (see AddIndex)
+type KeyIndex[T, K comparable] struct {
+	list *XList[T]
+	name string
+	idx  *keyIndex[T, K]
+}
+
+// AddIndex : registers unique index 'name' of list 'l' with key function 'key', returns its handle.
+// Returns ErrIndexExists if the name is taken, ErrDuplicateKey if two elements of the list have the same key.
+// Mutations that make two elements share a key are not rejected, but lookups of that key
+// return ErrDuplicateKey until one of them is removed or changed.
+// Indexes belong to the list: copies of the list don't have them.
+//
+// Example:
+//
+//	byID, _ := xlist.AddIndex(users, "id", func(u *User) int { return u.ID })
+//
+//	u, err := byID.LookupOne(42)
+func AddIndex[T, K comparable](l *XList[T], name string, key func(T) K) (*KeyIndex[T, K], error) {
+	return addIndex(l, name, key, true)
+}
+
+// AddMultiIndex : registers non-unique index 'name' of list 'l' with key function 'key' (see AddIndex)
+//
+// Example:
+//
+//	byCity, _ := xlist.AddMultiIndex(users, "city", func(u *User) string { return u.City })
+//
+//	berliners, _ := byCity.Lookup("Berlin")
+func AddMultiIndex[T, K comparable](l *XList[T], name string, key func(T) K) (*KeyIndex[T, K], error) {
+	return addIndex(l, name, key, false)
+}
+
+// DropIndex : removes index 'name', returns ErrIndexNotFound if there is no such index
+func (p *XList[T]) DropIndex(name string) error {
+	if p.reentrant() {
+		return p.deferCall(func() error { return p.DropIndex(name) })
+	}
+
+	p.lock()
+	defer p.unlock()
+
+	idx, ok := p.indexes[name]
+	if !ok {
+		return ErrIndexNotFound
+	}
+
+	p.unobserve(idx)
+	delete(p.indexes, name)
+
+	return nil
+}
+
+// Name : returns name of the index
+func (x *KeyIndex[T, K]) Name() string {
+	return x.name
+}
+
+// Lookup : returns elements with key 'key' in list order (empty if there are none).
+// Returns ErrIndexNotFound if the index was dropped, ErrDuplicateKey if the index is unique
+// and 'key' is shared by several elements.
+func (x *KeyIndex[T, K]) Lookup(key K) ([]T, error) {
+	p := x.list
+
+	var (
+		values []T
+		err    error
+	)
+
+	p.runCallbacks(false, func() {
+		if idx, ok := p.indexes[x.name]; !ok || idx != observer[T](x.idx) {
+			err = ErrIndexNotFound
+			return
+		}
+
+		x.idx.sync()
+		values, err = x.idx.lookup(key)
+	})
+
+	return values, err
+}
+
+// LookupOne : returns the element with key 'key' (see Lookup);
+// returns ErrElementNotFound if there is no such element, ErrDuplicateKey if there are several.
+func (x *KeyIndex[T, K]) LookupOne(key K) (T, error) {
+	var zero T
+
+	values, err := x.Lookup(key)
+	switch {
+	case err != nil:
+		return zero, err
+	case len(values) == 0:
+		return zero, ErrElementNotFound
+	case len(values) > 1:
+		return zero, fmt.Errorf("%w: %v", ErrDuplicateKey, key)
+	}
+
+	return values[0], nil
+}
+
+// addIndex : internal realisation of AddIndex and AddMultiIndex
+func addIndex[T, K comparable](l *XList[T], name string, key func(T) K, unique bool) (*KeyIndex[T, K], error) {
+	x := &KeyIndex[T, K]{
+		list: l,
+		name: name,
+		idx: &keyIndex[T, K]{
+			list:    l,
+			key:     key,
+			unique:  unique,
+			order:   make(map[*xlistObj[T]]uint64),
+			keys:    make(map[*xlistObj[T]]K),
+			buckets: make(map[K][]*xlistObj[T]),
+			dirty:   make(map[*xlistObj[T]]struct{}),
+		},
+	}
+
+	if l.reentrant() {
+		return x, l.deferCall(func() error { return x.register() })
+	}
+
+	if err := x.register(); err != nil {
+		return nil, err
+	}
+
+	return x, nil
+}
+
+// register : builds the index and subscribes it to changes of the list
+func (x *KeyIndex[T, K]) register() error {
+	p := x.list
+
+	var err error
+
+	p.runCallbacks(true, func() {
+		if _, ok := p.indexes[x.name]; ok {
+			err = ErrIndexExists
+			return
+		}
+
+		x.idx.build()
+		if x.idx.unique {
+			if err = x.idx.duplicate(); err != nil {
+				return
+			}
+		}
+
+		if p.indexes == nil {
+			p.indexes = make(map[string]observer[T])
+		}
+		p.indexes[x.name] = x.idx
+		p.observe(x.idx)
+	})
+
+	return err
+}
+
+// ----------------------------------------------------------
+
+// orderGap : distance between order labels of neighbour objects after relabel
+const orderGap = 1 << 32
+
+// keyIndex : objects of the list by key
+type keyIndex[T, K comparable] struct {
+	mtx sync.Mutex // lookups run concurrently under read lock of the list
+
+	list   *XList[T]
+	key    func(T) K
+	unique bool
+
+	order   map[*xlistObj[T]]uint64   // labels of objects growing from the head of the list to its tail
+	keys    map[*xlistObj[T]]K        // indexed objects and their keys at the check
+	buckets map[K][]*xlistObj[T]      // objects by key in list order
+	dirty   map[*xlistObj[T]]struct{} // linked or changed objects to check
+	stale   bool                      // keys of all objects have to be checked
+}
+
+// build : labels and indexes all objects of the list (internal: list is locked by caller)
+func (x *keyIndex[T, K]) build() {
+	x.mtx.Lock()
+	x.relabel()
+	x.stale = true
+	x.mtx.Unlock()
+
+	x.sync()
+}
+
+// duplicate : returns ErrDuplicateKey with the first key shared by several objects, nil if there is none
+// (internal: list is locked by caller)
+func (x *keyIndex[T, K]) duplicate() error {
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	for k, bucket := range x.buckets {
+		if len(bucket) > 1 {
+			return fmt.Errorf("%w: %v", ErrDuplicateKey, k)
+		}
+	}
+
+	return nil
+}
+
+// sync : checks keys of the recorded objects, moves objects whose keys changed
+// (internal: list is locked by caller)
+func (x *keyIndex[T, K]) sync() {
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	if x.stale {
+		x.stale = false
+		for xobj := x.list.home; xobj != nil; xobj = xobj.next {
+			x.check(xobj)
+		}
+	} else {
+		for xobj := range x.dirty {
+			x.check(xobj)
+		}
+	}
+	clear(x.dirty)
+}
+
+// lookup : returns values of objects with key 'key' in list order (internal: list is locked by caller).
+// Returns ErrDuplicateKey if the index is unique and 'key' is shared by several objects.
+func (x *keyIndex[T, K]) lookup(key K) ([]T, error) {
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	bucket := x.buckets[key]
+	if x.unique && len(bucket) > 1 {
+		return nil, fmt.Errorf("%w: %v", ErrDuplicateKey, key)
+	}
+
+	values := make([]T, len(bucket))
+	for i, xobj := range bucket {
+		values[i] = *xobj.obj
+	}
+
+	return values, nil
+}
+
+// check : indexes 'xobj' by its current key (mtx is locked by caller)
+func (x *keyIndex[T, K]) check(xobj *xlistObj[T]) {
+	k := x.key(*xobj.obj)
+	if old, ok := x.keys[xobj]; ok {
+		if old == k {
+			return
+		}
+		x.drop(xobj)
+	}
+
+	bucket := x.buckets[k]
+	i, _ := x.search(bucket, xobj)
+	x.buckets[k] = slices.Insert(bucket, i, xobj)
+	x.keys[xobj] = k
+}
+
+// drop : removes 'xobj' from the index (mtx is locked by caller)
+func (x *keyIndex[T, K]) drop(xobj *xlistObj[T]) {
+	k, ok := x.keys[xobj]
+	if !ok {
+		return
+	}
+	delete(x.keys, xobj)
+
+	bucket := x.buckets[k]
+	if i, found := x.search(bucket, xobj); found {
+		bucket = slices.Delete(bucket, i, i+1)
+	}
+
+	if len(bucket) == 0 {
+		delete(x.buckets, k)
+	} else {
+		x.buckets[k] = bucket
+	}
+}
+
+// search : returns position of 'xobj' in 'bucket' by its order label, 'true' if it's there (mtx is locked by caller)
+func (x *keyIndex[T, K]) search(bucket []*xlistObj[T], xobj *xlistObj[T]) (int, bool) {
+	return slices.BinarySearchFunc(bucket, x.order[xobj], func(b *xlistObj[T], label uint64) int {
+		return cmp.Compare(x.order[b], label)
+	})
+}
+
+// label : gives order labels to 'xobj' and the objects linked with it up to the next labelled one
+// (a chain linked at once), between the labels of their neighbours (mtx is locked by caller).
+// Relabels all objects when there is no room between the neighbours.
+func (x *keyIndex[T, K]) label(xobj *xlistObj[T]) {
+	if _, ok := x.order[xobj]; ok {
+		return
+	}
+
+	n := uint64(1)
+	next := xobj.next
+	for ; next != nil; next = next.next {
+		if _, ok := x.order[next]; ok {
+			break
+		}
+		n++
+	}
+
+	lo, hasLo := x.order[xobj.prev]
+	hi, hasHi := x.order[next]
+	if xobj.prev != nil && !hasLo {
+		x.relabel()
+		return
+	}
+
+	span := (n + 1) * orderGap
+	switch {
+	case hasLo && !hasHi:
+		hi = lo + span
+	case !hasLo && hasHi:
+		lo = hi - span
+	case !hasLo && !hasHi:
+		lo = math.MaxUint64 / 2
+		hi = lo + span
+	}
+
+	step := (hi - lo) / (n + 1)
+	if hi <= lo || step == 0 {
+		x.relabel()
+		return
+	}
+
+	for o := xobj; o != next; o = o.next {
+		lo += step
+		x.order[o] = lo
+	}
+}
+
+// relabel : gives evenly spread order labels to all objects of the list (mtx is locked by caller)
+func (x *keyIndex[T, K]) relabel() {
+	n := uint64(x.list.length())
+	gap := min(uint64(orderGap), math.MaxUint64/(n+2))
+	label := (math.MaxUint64 - gap*n) / 2
+
+	for xobj := x.list.home; xobj != nil; xobj = xobj.next {
+		x.order[xobj] = label
+		label += gap
+	}
+}
+
+func (x *keyIndex[T, K]) linked(xobj *xlistObj[T]) {
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	x.label(xobj)
+	x.dirty[xobj] = struct{}{}
+}
+
+func (x *keyIndex[T, K]) unlinked(xobj *xlistObj[T]) {
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	delete(x.dirty, xobj)
+	x.drop(xobj)
+	delete(x.order, xobj)
+}
+
+func (x *keyIndex[T, K]) cleared() {
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	clear(x.order)
+	clear(x.keys)
+	clear(x.buckets)
+	clear(x.dirty)
+	x.stale = false
+}
+
+func (x *keyIndex[T, K]) changed(xobj *xlistObj[T]) {
+	x.mtx.Lock()
+	defer x.mtx.Unlock()
+
+	if xobj == nil {
+		x.stale = true
+		return
+	}
+	x.dirty[xobj] = struct{}{}
+}
@@ -0,0 +1,130 @@
+// locking.go
+// Container locking: synchronized (default) and unsynchronized modes
+// Created by Vokhmin D.A. 10.2026
+
+package xlist
+
+import "sync/atomic"
+
+// lockSeqCounter : source of lock order numbers of lists
+var lockSeqCounter atomic.Uint64
+
+// lock : takes the write lock (no-op for unsynchronized lists).
+// Public methods check reentrancy once before locking (see reentrant): a call from a callback
+// of the list would deadlock here.
+func (p *XList[T]) lock() {
+	if p.opts.unsync {
+		p.checkOwner()
+		return
+	}
+	p.mtx.Lock()
+}
+
+// unlock : releases the write lock
+func (p *XList[T]) unlock() {
+	if p.opts.unsync {
+		return
+	}
+	p.mtx.Unlock()
+}
+
+// rlock : takes the read lock, returns 'false' if it's not taken: for unsynchronized lists
+// and inside a callback of the list, where the lock is already held by the outer operation.
+// The callback scopes are looked up only if the lock is not free (a writer holds or waits for it).
+// Release with runlock:
+//
+//	defer p.runlock(p.rlock())
+func (p *XList[T]) rlock() bool {
+	if p.opts.unsync {
+		if !p.reentrant() {
+			p.checkOwner()
+		}
+		return false
+	}
+
+	if p.mtx.TryRLock() {
+		return true
+	}
+	if p.currentCallbackState() != nil {
+		return false
+	}
+
+	p.mtx.RLock()
+
+	return true
+}
+
+// runlock : releases the read lock taken by rlock ('locked' is its result)
+func (p *XList[T]) runlock(locked bool) {
+	if locked {
+		p.mtx.RUnlock()
+	}
+}
+
+// adopt : hands over a temporary list built by 'newTemp' with the options of receiver,
+// so the result behaves the same way as the list it was made from.
+func (p *XList[T]) adopt(tmp *XList[T]) *XList[T] {
+	return adoptAs(p, tmp)
+}
+
+// adoptAs : same as 'adopt' for a temporary list of another element type
+func adoptAs[T, V comparable](src *XList[T], tmp *XList[V]) *XList[V] {
+	tmp.opts = src.opts
+	tmp.owner.Store(0)
+
+	return tmp
+}
+
+// lockSeq : returns the lock order of the list, assigned on first use.
+func (p *XList[T]) lockSeq() uint64 {
+	if seq := p.seq.Load(); seq != 0 {
+		return seq
+	}
+
+	p.seq.CompareAndSwap(0, lockSeqCounter.Add(1))
+
+	return p.seq.Load()
+}
+
+// lockPair : takes write locks of two different lists in a stable order (avoids deadlock
+// when two goroutines lock the same pair in opposite order).
+// Reentrancy of 'a' is checked by the caller; panics with ErrReentrantCall when called from a callback of 'b'.
+// Returns function that releases both locks.
+func lockPair[T comparable](a, b *XList[T]) func() {
+	if b.reentrant() {
+		panic(ErrReentrantCall)
+	}
+
+	if a.lockSeq() > b.lockSeq() {
+		a, b = b, a
+	}
+
+	a.lock()
+	b.lock()
+
+	return func() {
+		b.unlock()
+		a.unlock()
+	}
+}
+
+// rlockPair : takes read locks of two lists in a stable order (see lockPair); 'a' and 'b' can be the same list.
+// Returns function that releases the locks.
+func rlockPair[T comparable](a, b *XList[T]) func() {
+	if a == b {
+		locked := a.rlock()
+		return func() { a.runlock(locked) }
+	}
+
+	if a.lockSeq() > b.lockSeq() {
+		a, b = b, a
+	}
+
+	lockedA := a.rlock()
+	lockedB := b.rlock()
+
+	return func() {
+		b.runlock(lockedB)
+		a.runlock(lockedA)
+	}
+}
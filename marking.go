@@ -4,6 +4,16 @@ package xlist
 
 // MarkAtIndex : mark element at specified index
 func (p *XList[T]) MarkAtIndex(index int) {
+	if p.reentrant() {
+		if err := p.deferAction(func() { p.MarkAtIndex(index) }); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	p.lock()
+	defer p.unlock()
+
 	xObj := p.goToPosition(index)
 	if xObj != nil {
 		xObj.mark = true
@@ -12,6 +22,16 @@ func (p *XList[T]) MarkAtIndex(index int) {
 
 // UnmarkAtIndex : clear mark of element at specified index
 func (p *XList[T]) UnmarkAtIndex(index int) {
+	if p.reentrant() {
+		if err := p.deferAction(func() { p.UnmarkAtIndex(index) }); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	p.lock()
+	defer p.unlock()
+
 	xObj := p.goToPosition(index)
 	if xObj != nil {
 		xObj.mark = false
@@ -20,6 +40,8 @@ func (p *XList[T]) UnmarkAtIndex(index int) {
 
 // IsMarkedAtIndex : returns 'true' if element at specified index is marked
 func (p *XList[T]) IsMarkedAtIndex(index int) bool {
+	defer p.runlock(p.rlock())
+
 	xObj := p.goToPosition(index)
 	if xObj != nil {
 		return xObj.mark
@@ -30,8 +52,15 @@ func (p *XList[T]) IsMarkedAtIndex(index int) bool {
 
 // MarkAll : mark all elements
 func (p *XList[T]) MarkAll() {
-	p.mtx.Lock()
-	defer p.mtx.Unlock()
+	if p.reentrant() {
+		if err := p.deferAction(func() { p.MarkAll() }); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	p.lock()
+	defer p.unlock()
 
 	for xobj := p.home; xobj != nil; xobj = xobj.next {
 		xobj.mark = true
@@ -40,8 +69,15 @@ func (p *XList[T]) MarkAll() {
 
 // UnmarkAll : clear mark of all elements
 func (p *XList[T]) UnmarkAll() {
-	p.mtx.Lock()
-	defer p.mtx.Unlock()
+	if p.reentrant() {
+		if err := p.deferAction(func() { p.UnmarkAll() }); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	p.lock()
+	defer p.unlock()
 
 	for xobj := p.home; xobj != nil; xobj = xobj.next {
 		xobj.mark = false
@@ -0,0 +1,72 @@
+// observers.go
+// Internal subscribers of changes of the list
+// Created by Vokhmin D.A. 10.2026
+
+package xlist
+
+// observer : receives structural and value changes of the list (pagination cursors, views, indexes).
+// Methods are called under the write lock of the list.
+type observer[T comparable] interface {
+	linked(xobj *xlistObj[T])   // object is linked into the chain
+	unlinked(xobj *xlistObj[T]) // object is removed from the chain
+	cleared()                   // all objects are removed (dropped or moved to another list)
+	changed(xobj *xlistObj[T])  // value of the object is replaced, nil - values of many objects (Modify, Sort, ...)
+}
+
+// observe : subscribes 'o' to changes of the list.
+// The list must be locked by caller (write lock, or read lock if callers of observe are serialized).
+func (p *XList[T]) observe(o observer[T]) {
+	p.observers = append(p.observers, o)
+}
+
+// unobserve : unsubscribes 'o' (write lock must be held by caller)
+func (p *XList[T]) unobserve(o observer[T]) {
+	for i, obs := range p.observers {
+		if obs == o {
+			p.observers = append(p.observers[:i], p.observers[i+1:]...)
+			return
+		}
+	}
+}
+
+func (p *XList[T]) notifyLinked(xobj *xlistObj[T]) {
+	for _, o := range p.observers {
+		o.linked(xobj)
+	}
+}
+
+// notifyLinkedChain : notifies about objects from 'home' to 'end' linked at once (splice)
+func (p *XList[T]) notifyLinkedChain(home, end *xlistObj[T]) {
+	if len(p.observers) == 0 {
+		return
+	}
+
+	for xobj := home; xobj != nil; xobj = xobj.next {
+		p.notifyLinked(xobj)
+		if xobj == end {
+			break
+		}
+	}
+}
+
+func (p *XList[T]) notifyUnlinked(xobj *xlistObj[T]) {
+	for _, o := range p.observers {
+		o.unlinked(xobj)
+	}
+}
+
+func (p *XList[T]) notifyCleared() {
+	for _, o := range p.observers {
+		o.cleared()
+	}
+}
+
+// valueChanged : counts a value change for fail-fast iterators and notifies observers
+// ('xobj' = nil - values of many objects changed)
+func (p *XList[T]) valueChanged(xobj *xlistObj[T]) {
+	p.valCount++
+
+	for _, o := range p.observers {
+		o.changed(xobj)
+	}
+}
@@ -0,0 +1,23 @@
+// owner-debug.go
+// Debug build: asserts that an unsynchronized list is used by one goroutine only
+// Created by Vokhmin D.A. 10.2026
+
+//go:build xlist_debug
+
+package xlist
+
+import "fmt"
+
+// checkOwner : panics if an unsynchronized list is touched by more than one goroutine.
+// The first goroutine that touches the list becomes its owner.
+func (p *XList[T]) checkOwner() {
+	id := goid()
+
+	if p.owner.CompareAndSwap(0, id) {
+		return
+	}
+
+	if owner := p.owner.Load(); owner != id {
+		panic(fmt.Sprintf("xlist: unsynchronized list owned by goroutine %d is used by goroutine %d", owner, id))
+	}
+}
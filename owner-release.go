@@ -0,0 +1,10 @@
+// owner-release.go
+// Release build: ownership of unsynchronized lists is not checked
+// Created by Vokhmin D.A. 10.2026
+
+//go:build !xlist_debug
+
+package xlist
+
+// checkOwner : no-op, build with '-tags xlist_debug' to enable the check.
+func (p *XList[T]) checkOwner() {}
@@ -0,0 +1,23 @@
+//go:build xlist_debug
+
+package xlist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnsyncOwnerCheck(t *testing.T) {
+	list := NewWithOptions[int](WithUnsync())
+	list.Append(1, 2, 3)
+
+	done := make(chan any)
+	go func() {
+		defer func() { done <- recover() }()
+		list.Append(4)
+	}()
+
+	assert.NotNil(t, <-done)
+	assert.Equal(t, 3, list.Size())
+}
@@ -0,0 +1,218 @@
+// pagination.go
+// Cursor-based pagination of the list
+// Created by Vokhmin D.A. 10.2026
+
+package xlist
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+)
+
+// Page : one page of the list returned by XList.Page
+type Page[T comparable] struct {
+	Items []T
+
+	Next string // cursor of the next page, "" - no elements after the page
+	Prev string // cursor of the previous page, "" - no elements before the page
+}
+
+// cursor directions
+const (
+	cursorAfter  byte = 'a' // page starts after the cursor object
+	cursorBefore byte = 'b' // page ends before the cursor object
+)
+
+const cursorLen = 17 // nonce (8 bytes) + direction (1 byte) + object id (8 bytes)
+
+// Page : returns up to 'limit' elements starting from 'cursor' ("" - from the beginning of the list)
+// and cursors of the next and previous pages.
+//
+// A cursor is an opaque string tied to an element of the list, not to its index:
+//   - it survives inserts and deletes of other elements; the next page starts right after
+//     the last element of the current page, whatever happened to the list before it;
+//   - if the cursor element is deleted (or the list is cleared), Page returns ErrCursorNotFound:
+//     start again from the beginning;
+//   - a cursor of another list, or a broken one, gives ErrInvalidCursor.
+//
+// Sort and Swap move values between elements, so cursors follow positions, not values, after them.
+//
+// Example:
+//
+//	page, err := list.Page(req.Cursor, 20)
+//	if err != nil {
+//		return err
+//	}
+//	resp := Response{Items: page.Items, Next: page.Next, Prev: page.Prev}
+func (p *XList[T]) Page(cursor string, limit int) (Page[T], error) {
+	if limit < 1 {
+		return Page[T]{}, fmt.Errorf("%w: limit=%d", ErrInvalidRange, limit)
+	}
+
+	defer p.runlock(p.rlock())
+
+	reg := p.pageRegistry()
+
+	var first, last *xlistObj[T]
+
+	if cursor == "" {
+		first, last = pageForward(p.home, limit)
+	} else {
+		dir, xobj, err := reg.resolve(cursor)
+		if err != nil {
+			return Page[T]{}, err
+		}
+
+		if dir == cursorAfter {
+			first, last = pageForward(xobj.next, limit)
+		} else {
+			first, last = pageBackward(xobj.prev, limit)
+		}
+	}
+
+	page := Page[T]{}
+	if first == nil {
+		return page, nil
+	}
+
+	for xobj := first; ; xobj = xobj.next {
+		page.Items = append(page.Items, *xobj.obj)
+		if xobj == last {
+			break
+		}
+	}
+
+	if last.next != nil {
+		page.Next = reg.cursor(cursorAfter, last)
+	}
+	if first.prev != nil {
+		page.Prev = reg.cursor(cursorBefore, first)
+	}
+
+	return page, nil
+}
+
+// pageForward : returns the first and the last object of the page of 'limit' objects starting from 'from'
+func pageForward[T comparable](from *xlistObj[T], limit int) (*xlistObj[T], *xlistObj[T]) {
+	if from == nil {
+		return nil, nil
+	}
+
+	last := from
+	for i := 1; i < limit && last.next != nil; i++ {
+		last = last.next
+	}
+
+	return from, last
+}
+
+// pageBackward : returns the first and the last object of the page of 'limit' objects ending with 'to'
+func pageBackward[T comparable](to *xlistObj[T], limit int) (*xlistObj[T], *xlistObj[T]) {
+	if to == nil {
+		return nil, nil
+	}
+
+	first := to
+	for i := 1; i < limit && first.prev != nil; i++ {
+		first = first.prev
+	}
+
+	return first, to
+}
+
+// pageRegistry : returns registry of cursor objects, creates it at the first call (list is locked by caller)
+func (p *XList[T]) pageRegistry() *pageRegistry[T] {
+	p.pagesOnce.Do(func() {
+		p.pages = &pageRegistry[T]{
+			nonce: rand.Uint64(),
+			ids:   make(map[*xlistObj[T]]uint64),
+			objs:  make(map[uint64]*xlistObj[T]),
+		}
+		// writers are locked out by the caller, observe calls are serialized by Once
+		p.observe(p.pages)
+	})
+
+	return p.pages
+}
+
+// ----------------------------------------------------------
+
+// pageRegistry : stable ids of the objects used as cursors.
+// Ids are given on demand and never reused; deleted objects leave the registry.
+type pageRegistry[T comparable] struct {
+	mtx sync.Mutex // Page calls run concurrently under read lock of the list
+
+	nonce  uint64 // identity of the list in cursors
+	lastID uint64
+	ids    map[*xlistObj[T]]uint64
+	objs   map[uint64]*xlistObj[T]
+}
+
+// cursor : returns cursor of object 'xobj' in direction 'dir'
+func (r *pageRegistry[T]) cursor(dir byte, xobj *xlistObj[T]) string {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	id, ok := r.ids[xobj]
+	if !ok {
+		r.lastID++
+		id = r.lastID
+		r.ids[xobj] = id
+		r.objs[id] = xobj
+	}
+
+	buf := make([]byte, cursorLen)
+	binary.BigEndian.PutUint64(buf[0:8], r.nonce)
+	buf[8] = dir
+	binary.BigEndian.PutUint64(buf[9:17], id)
+
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// resolve : returns direction and object of the cursor
+func (r *pageRegistry[T]) resolve(cursor string) (byte, *xlistObj[T], error) {
+	buf, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(buf) != cursorLen {
+		return 0, nil, ErrInvalidCursor
+	}
+
+	dir := buf[8]
+	if binary.BigEndian.Uint64(buf[0:8]) != r.nonce || (dir != cursorAfter && dir != cursorBefore) {
+		return 0, nil, ErrInvalidCursor
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	xobj, ok := r.objs[binary.BigEndian.Uint64(buf[9:17])]
+	if !ok {
+		return 0, nil, ErrCursorNotFound
+	}
+
+	return dir, xobj, nil
+}
+
+func (r *pageRegistry[T]) linked(*xlistObj[T]) {}
+
+func (r *pageRegistry[T]) changed(*xlistObj[T]) {}
+
+func (r *pageRegistry[T]) unlinked(xobj *xlistObj[T]) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if id, ok := r.ids[xobj]; ok {
+		delete(r.ids, xobj)
+		delete(r.objs, id)
+	}
+}
+
+func (r *pageRegistry[T]) cleared() {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	clear(r.ids)
+	clear(r.objs)
+}
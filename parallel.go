@@ -0,0 +1,231 @@
+// parallel.go
+// Parallel bulk operations: ParallelFind, ParallelModify, ParallelForEach
+// Created by Vokhmin D.A. 10.2026
+
+package xlist
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// The chain is split into segments of consecutive elements, one per worker goroutine.
+// Workers are marked as running callbacks of the list, like the calling goroutine:
+// callbacks can read the list, mutations fail with ErrReentrantCall or are deferred
+// (see WithDeferredReentrancy) and applied after the operation in list order.
+// A panic in a callback stops the other workers and is re-raised in the calling goroutine.
+
+// defaultMinSegment : default minimal number of elements per worker
+const defaultMinSegment = 256
+
+// BulkOptions : options of bulk operations
+// (WithWorkers, WithMinSegment - parallel operations, WithRollback - ModifyCtx)
+type BulkOptions struct {
+	workers    int // 0 - GOMAXPROCS
+	minSegment int
+	rollback   bool
+}
+
+// WithWorkers : limits number of worker goroutines (GOMAXPROCS by default; 1 - no goroutines)
+func WithWorkers(n int) func(*BulkOptions) {
+	return func(o *BulkOptions) {
+		o.workers = n
+	}
+}
+
+// WithMinSegment : minimal number of elements per worker (256 by default).
+// Lower it for expensive callbacks: WithMinSegment(1) gives a worker to each element of a short list.
+func WithMinSegment(n int) func(*BulkOptions) {
+	return func(o *BulkOptions) {
+		o.minSegment = n
+	}
+}
+
+// bulkParams : returns options with defaults applied
+func bulkParams(opt []func(*BulkOptions)) BulkOptions {
+	o := BulkOptions{}
+	for _, optSet := range opt {
+		optSet(&o)
+	}
+
+	if o.workers < 1 {
+		o.workers = runtime.GOMAXPROCS(0)
+	}
+	if o.minSegment < 1 {
+		o.minSegment = defaultMinSegment
+	}
+
+	return o
+}
+
+// ParallelFind : same as Find, but calls 'is' concurrently in worker goroutines.
+// The result keeps the order of the list.
+//
+// Example:
+//
+//	matches := list.ParallelFind(func(_ int, doc Doc) bool {
+//		return expensiveMatch(doc)
+//	}, xlist.WithWorkers(8), xlist.WithMinSegment(1))
+func (p *XList[T]) ParallelFind(is func(index int, object T) bool, opt ...func(*BulkOptions)) *XList[T] {
+	var found [][]T
+
+	p.runParallel(false, bulkParams(opt), func(segments int) {
+		found = make([][]T, segments)
+	}, func(seg int, index int, xobj *xlistObj[T]) {
+		if is(index, *xobj.obj) {
+			found[seg] = append(found[seg], *xobj.obj)
+		}
+	})
+
+	newList := newTemp[T]()
+	for _, objects := range found {
+		newList.append(objects...)
+	}
+
+	return p.adopt(newList)
+}
+
+// ParallelModify : same as Modify, but calls 'change' concurrently in worker goroutines.
+// The order of calls is not defined, so there is no parallel ModifyRev.
+// Returns self for method chaining; return value can be ignored.
+func (p *XList[T]) ParallelModify(change func(index int, object T) T, opt ...func(*BulkOptions)) *XList[T] {
+	if p.reentrant() {
+		if err := p.deferAction(func() { p.ParallelModify(change, opt...) }); err != nil {
+			panic(err)
+		}
+		return p
+	}
+
+	p.runParallel(true, bulkParams(opt), func(int) {
+		p.valueChanged(nil)
+	}, func(_ int, index int, xobj *xlistObj[T]) {
+		*xobj.obj = change(index, *xobj.obj)
+	})
+
+	return p
+}
+
+// ParallelForEach : calls 'fn' for each element concurrently in worker goroutines (under read lock of the list)
+func (p *XList[T]) ParallelForEach(fn func(index int, object T), opt ...func(*BulkOptions)) {
+	p.runParallel(false, bulkParams(opt), nil, func(_ int, index int, xobj *xlistObj[T]) {
+		fn(index, *xobj.obj)
+	})
+}
+
+// segment : consecutive elements of the list processed by one worker
+type segment[T comparable] struct {
+	first *xlistObj[T]
+	index int // index of 'first'
+	count int
+}
+
+// segments : splits the list into not more than 'workers' segments of at least 'minSegment' elements
+// (internal: list is locked by caller)
+func (p *XList[T]) segments(workers, minSegment int) []segment[T] {
+	n := p.length()
+	if n == 0 {
+		return nil
+	}
+
+	k := min(workers, max(1, n/minSegment))
+	size, rest := n/k, n%k
+
+	segs := make([]segment[T], 0, k)
+	xobj, index := p.home, 0
+
+	for i := range k {
+		count := size
+		if i < rest {
+			count++
+		}
+
+		segs = append(segs, segment[T]{first: xobj, index: index, count: count})
+
+		for range count {
+			xobj = xobj.next
+		}
+		index += count
+	}
+
+	return segs
+}
+
+// runParallel : runs 'fn' for each element of the list in worker goroutines under the lock of the list.
+// 'prepare' (optional) is called under the lock with number of segments before the workers start.
+// Deferred mutations of the workers are applied after the lock is released; a panic of a worker
+// is re-raised in the calling goroutine.
+func (p *XList[T]) runParallel(write bool, o BulkOptions, prepare func(segments int), fn func(seg, index int, xobj *xlistObj[T])) {
+	var pending [][]func() error
+
+	p.runCallbacks(write, func() {
+		segs := p.segments(o.workers, o.minSegment)
+		if prepare != nil {
+			prepare(len(segs))
+		}
+
+		// one segment: the calling goroutine does the work
+		if len(segs) == 1 {
+			runSegment(0, segs[0], fn, nil)
+			return
+		}
+
+		var (
+			wg       sync.WaitGroup
+			stopped  atomic.Bool
+			panicMtx sync.Mutex
+			panicked bool
+			panicVal any
+		)
+
+		pending = make([][]func() error, len(segs))
+
+		for i, seg := range segs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() {
+					if r := recover(); r != nil {
+						stopped.Store(true)
+
+						panicMtx.Lock()
+						if !panicked {
+							panicked, panicVal = true, r
+						}
+						panicMtx.Unlock()
+					}
+				}()
+
+				state := p.enterCallbacks()
+				defer func() {
+					pending[i] = p.leaveCallbacks(state)
+				}()
+
+				runSegment(i, seg, fn, &stopped)
+			}()
+		}
+
+		wg.Wait()
+
+		if panicked {
+			panic(panicVal)
+		}
+	})
+
+	for _, mutations := range pending {
+		p.applyDeferred(mutations)
+	}
+}
+
+// runSegment : calls 'fn' for elements of segment 'seg', stops early when 'stopped' is set
+func runSegment[T comparable](i int, seg segment[T], fn func(seg, index int, xobj *xlistObj[T]), stopped *atomic.Bool) {
+	xobj := seg.first
+	for n := range seg.count {
+		if stopped != nil && stopped.Load() {
+			return
+		}
+
+		fn(i, seg.index+n, xobj)
+		xobj = xobj.next
+	}
+}
@@ -0,0 +1,174 @@
+// range-mut.go
+// Range iterators yielding references to elements for in-place editing
+// Created by Vokhmin D.A. 10.2026
+
+package xlist
+
+import "iter"
+
+// Ref : reference to an element of the list, yielded by AllMut/BackwardMut.
+// The reference is valid during its step of the loop only and must be used by the loop goroutine,
+// later calls return ErrStaleReference (or panic with it).
+type Ref[T comparable] struct {
+	list  *XList[T]
+	xobj  *xlistObj[T]
+	index int
+
+	inserted int  // elements inserted after the referenced one
+	stale    bool // the loop moved on
+}
+
+// AllMut returns a forward iterator over the list yielding (index, *Ref[T]).
+// The list is write locked for the whole loop; the element is edited through the reference:
+// Set, Delete, Mark, Unmark, InsertAfter. Other mutations of the list from the loop body
+// fail with ErrReentrantCall (see WithDeferredReentrancy).
+// References must be used by the loop goroutine, so the loop can't be pulled with iter.Pull
+// (panics with ErrPulledLoop).
+// Options: WithPos/WithEnd/WithCount/WithStep/WithMarkedOnly select the elements.
+//   - deleted element: the loop goes on with the element that followed it (at the same index);
+//   - elements inserted after the current one are not visited, indexes of the following elements shift.
+//
+// Example:
+//
+//	for _, ref := range list.AllMut() {
+//		if ref.Get() < 0 {
+//			ref.Delete()
+//		}
+//	}
+func (p *XList[T]) AllMut(opt ...func(*RangeOptions)) iter.Seq2[int, *Ref[T]] {
+	return p.iterateMut(rangeParams(opt), true)
+}
+
+// BackwardMut returns a reverse iterator over the list yielding (index, *Ref[T]).
+// Same as AllMut, but goes from end to start; elements inserted after the current one are behind the loop.
+func (p *XList[T]) BackwardMut(opt ...func(*RangeOptions)) iter.Seq2[int, *Ref[T]] {
+	return p.iterateMut(rangeParams(opt), false)
+}
+
+// iterateMut : the whole loop runs under write lock, references edit the list directly
+func (p *XList[T]) iterateMut(params *RangeOptions, forward direction) iter.Seq2[int, *Ref[T]] {
+	return func(yield func(int, *Ref[T]) bool) {
+		if pulled(yield) {
+			panic(ErrPulledLoop)
+		}
+		if p.reentrant() {
+			panic(ErrReentrantCall)
+		}
+
+		p.runCallbacks(true, func() {
+			r := p.rangeOf(params, forward)
+			if r == nil {
+				return
+			}
+
+			xobj, index := p.rangeFirst(r)
+
+			for count := 0; xobj != nil && (count < r.limit || r.limit == 0); count++ {
+				// the neighbour is taken before the step: it doesn't change with Delete/InsertAfter of the current element
+				next := xobj.step(forward)
+
+				ref := &Ref[T]{list: p, xobj: xobj, index: index}
+				ok := yield(index, ref)
+				ref.stale = true
+
+				if !ok {
+					return
+				}
+
+				// following indexes (and the end of the range) shift with the edits
+				if !forward {
+					index--
+				} else if ref.xobj.removed {
+					index += ref.inserted
+					r.end += ref.inserted - 1
+				} else {
+					index += ref.inserted + 1
+					r.end += ref.inserted
+				}
+
+				xobj, index = rangeSeek(r, next, index, r.step-1)
+			}
+		})
+	}
+}
+
+// Get : returns value of the element
+func (r *Ref[T]) Get() T {
+	r.check()
+	return *r.xobj.obj
+}
+
+// Index : returns index of the element
+func (r *Ref[T]) Index() int {
+	r.check()
+	return r.index
+}
+
+// IsMarked : returns 'true' if the element is marked
+func (r *Ref[T]) IsMarked() bool {
+	r.check()
+	return r.xobj.mark
+}
+
+// Set : replaces value of the element
+func (r *Ref[T]) Set(obj T) error {
+	return r.edit(func() {
+		r.xobj.obj = &obj
+		r.list.valueChanged(r.xobj)
+	})
+}
+
+// Delete : deletes the element from the list
+func (r *Ref[T]) Delete() error {
+	return r.edit(func() {
+		r.list.unlink(r.xobj)
+	})
+}
+
+// Mark : marks the element
+func (r *Ref[T]) Mark() error {
+	return r.edit(func() {
+		r.xobj.mark = true
+	})
+}
+
+// Unmark : clears mark of the element
+func (r *Ref[T]) Unmark() error {
+	return r.edit(func() {
+		r.xobj.mark = false
+	})
+}
+
+// InsertAfter : inserts 'objects' after the element, the loop doesn't visit them
+func (r *Ref[T]) InsertAfter(objects ...T) error {
+	return r.edit(func() {
+		next := r.xobj.next
+		for _, obj := range objects {
+			r.list.linkBefore(next, &xlistObj[T]{obj: &obj})
+		}
+		r.inserted += len(objects)
+	})
+}
+
+// check : panics if the reference is used outside of its loop step
+func (r *Ref[T]) check() {
+	if r.stale {
+		panic(ErrStaleReference)
+	}
+}
+
+// edit : runs 'fn' if the reference is valid and its element is in the list
+// (the list is write locked by the loop)
+func (r *Ref[T]) edit(fn func()) error {
+	if r.stale {
+		return ErrStaleReference
+	}
+
+	if r.xobj.removed {
+		return ErrElementNotFound
+	}
+
+	fn()
+
+	return nil
+}
@@ -7,92 +7,281 @@ package xlist
 import (
 	"fmt"
 	"iter"
+	"math"
 )
 
 type direction bool
 
+// iterMode : how range iterators hold the lock of the list
+type iterMode int
+
+const (
+	iterLocked   iterMode = iota // read lock for the whole loop (default)
+	iterSnapshot                 // copy under read lock, then iterate lock-free
+	iterLive                     // read lock for each step only
+)
+
 type RangeOptions struct {
 	index int
 	count int
+	end   int
+	step  int
+
+	hasPos     bool // position is defined
+	hasEnd     bool // end is defined
+	markedOnly bool
+
+	mode        iterMode
+	failFast    bool // live mode panics on modification of the list
+	allowValues bool // value modifications are tolerated by fail-fast iteration
+	noPanic     bool // invalid range gives empty iteration (TryAll/TryBackward)
 }
 
+// WithPos : index of the first element of the range (All) or the last one (Backward).
+// Position equal to the list size gives empty range, other positions outside the list are invalid.
 func WithPos(pos int) func(*RangeOptions) {
 	return func(io *RangeOptions) {
 		io.index = pos
+		io.hasPos = true
 	}
 }
 
+// WithCount : max number of yielded elements (negative count is taken by absolute value, 0 - no limit).
 func WithCount(count int) func(*RangeOptions) {
 	return func(io *RangeOptions) {
 		io.count = count
 	}
 }
 
-// ----------------------------------------------------------
+// WithEnd : exclusive bound of the range: All goes up to 'end'-1, Backward goes down to 'end'+1.
+// The bound beyond the list is clamped to the list, the bound before the position is invalid.
+func WithEnd(end int) func(*RangeOptions) {
+	return func(io *RangeOptions) {
+		io.end = end
+		io.hasEnd = true
+	}
+}
 
-// All returns a forward iterator over the list.
-// Each element is yielded as (index, T).
-// Options: WithPos/WithCount can limit the range.
-//
-// Example:
+// WithStep : yields every 'step'-th element of the range starting from the first one ('step' >= 1).
+func WithStep(step int) func(*RangeOptions) {
+	return func(io *RangeOptions) {
+		io.step = step
+	}
+}
+
+// WithMarkedOnly : yields marked elements only (of the elements selected by WithStep).
+func WithMarkedOnly() func(*RangeOptions) {
+	return func(io *RangeOptions) {
+		io.markedOnly = true
+	}
+}
+
+// WithLocked : the iterator holds the read lock of the list for the whole loop (default mode).
+// Writers wait until the loop is finished; mutations of the list from the loop body
+// fail with ErrReentrantCall (see WithDeferredReentrancy).
+// The loop body runs as a callback of the list: its goroutine is locked to the OS thread, so it must not
+// resume coroutines of iter.Pull created outside of the loop. A loop pulled with iter.Pull (directly,
+// through ToValues or by Pull, Zip, ...) yields a copy of the range, like WithSnapshot.
+func WithLocked() func(*RangeOptions) {
+	return func(io *RangeOptions) {
+		io.mode = iterLocked
+	}
+}
+
+// WithSnapshot : the iterator copies the range under the read lock first and then
+// iterates the copy without lock. The loop body may modify the list freely;
+// the loop sees the list as it was at the start.
+func WithSnapshot() func(*RangeOptions) {
+	return func(io *RangeOptions) {
+		io.mode = iterSnapshot
+	}
+}
+
+// WithLive : the iterator takes the read lock for each step only, the loop body runs without lock
+// and may modify the list. The iterator stays on the node of the last yielded element:
+//   - elements inserted ahead of the cursor are visited, elements inserted behind it are not;
+//   - elements deleted ahead of the cursor are not visited;
+//   - if the current element is deleted, iteration goes on from its former neighbour;
+//   - yielded index is the actual position of the element
+//     (recalculated with O(n) walk after a structural change of the list);
+//   - if the list is cleared (or its chain is moved out by Splice) iteration stops.
 //
-//	for i, obj := range list.All(xlist.WithPos(2), xlist.WithCount(3)) {
-//		fmt.Println(i, obj)
-//	}
-func (p *XList[T]) All(opt ...func(*RangeOptions)) iter.Seq2[int, T] {
-	return func(yield func(int, T) bool) {
-		var tmp *xlistObj[T]
-		var xobjList []*xlistObj[T]
+// WithCount limits the number of yielded elements. Without WithEnd the loop goes on up to the end
+// of the list as it is at each step; WithEnd bounds the actual positions of elements.
+func WithLive() func(*RangeOptions) {
+	return func(io *RangeOptions) {
+		io.mode = iterLive
+	}
+}
 
-		params := &RangeOptions{}
+// WithFailFast : the live mode panics with ErrConcurrentModification at the next step
+// if the list was modified (by the loop body or by another goroutine) since the previous step,
+// instead of following the changes.
+// Value modifications (Replace, ReplaceLast, Swap, Modify, Sort) count too, unless WithAllowValueChanges is set.
+func WithFailFast() func(*RangeOptions) {
+	return func(io *RangeOptions) {
+		io.failFast = true
+	}
+}
 
-		p.mtx.RLock()
-		defer p.mtx.RUnlock()
+// WithAllowValueChanges : fail-fast iteration tolerates value modifications of the list;
+// structural modifications (insert, delete, clear, splice) still panic with ErrConcurrentModification.
+func WithAllowValueChanges() func(*RangeOptions) {
+	return func(io *RangeOptions) {
+		io.allowValues = true
+	}
+}
 
-		tmp = p.home
-		if len(opt) > 0 {
-			for _, optSet := range opt {
-				optSet(params)
-			}
+// rangeParams : applies range options
+func rangeParams(opt []func(*RangeOptions)) *RangeOptions {
+	params := &RangeOptions{step: 1}
 
-			if params.index < 0 || params.index >= p.size {
-				panic(fmt.Sprintf("%v: index=%d, xlist size size=%d", ErrInvalidIndex, params.index, p.size))
-			}
-			// avoid negative indexes
-			if params.count < 0 {
-				params.count = -1 * params.count
-			}
+	for _, optSet := range opt {
+		optSet(params)
+	}
 
-			// avoid over range
-			if (params.index + params.count) > p.size-1 {
-				params.count = p.size - params.index
-			}
+	return params
+}
 
-			// second param is speculative gos thru (to get CPU cache)
-			xobjList = p.getObjectsAt(params.index, params.index+params.count)
-			if len(xobjList) > 0 {
-				tmp = xobjList[0]
-			}
+// rangeSpec : range of iteration resolved against the list size
+type rangeSpec struct {
+	start      int // index of the first element
+	end        int // exclusive bound in the direction of iteration
+	step       int
+	limit      int // max number of yielded elements, 0 - no limit
+	markedOnly bool
+	forward    direction
+}
+
+// resolve : checks options against the list size, returns the range of iteration
+func (params *RangeOptions) resolve(size int, forward direction) (*rangeSpec, error) {
+	if params.step < 1 {
+		return nil, fmt.Errorf("%w: step=%d", ErrInvalidRange, params.step)
+	}
+
+	r := &rangeSpec{step: params.step, markedOnly: params.markedOnly, forward: forward}
+
+	if forward {
+		r.start, r.end = 0, size
+	} else {
+		r.start, r.end = size-1, -1
+	}
+
+	if params.hasPos {
+		if params.index < 0 || params.index > size {
+			return nil, fmt.Errorf("%w: index=%d, xlist size=%d", ErrInvalidIndex, params.index, size)
 		}
+		r.start = params.index
+	}
 
-		index := params.index
-		count := 0
-		for tmp != nil && (count < params.count || params.count == 0) {
-			if !yield(index, *tmp.obj) {
-				return
+	if params.hasEnd {
+		end := min(max(params.end, -1), size)
+		if (forward && end < r.start) || (!forward && end > r.start) {
+			return nil, fmt.Errorf("%w: pos=%d, end=%d", ErrInvalidRange, r.start, params.end)
+		}
+		r.end = end
+	}
+
+	r.limit = params.count
+	if r.limit < 0 {
+		r.limit = -r.limit
+	}
+
+	return r, nil
+}
+
+// unbound : drops the end of the range taken from the list size, 'inBounds' is always true then
+func (r *rangeSpec) unbound() {
+	if r.forward {
+		r.end = math.MaxInt
+	} else {
+		r.end = math.MinInt
+	}
+}
+
+// inBounds : checks that 'index' doesn't reach the end of the range
+func (r *rangeSpec) inBounds(index int) bool {
+	if r.forward {
+		return index < r.end
+	}
+	return index > r.end
+}
+
+// rangeOf : resolves the range of iteration over the list.
+// Invalid range panics, or gives nil for TryAll/TryBackward. The list must be locked by caller.
+func (p *XList[T]) rangeOf(params *RangeOptions, forward direction) *rangeSpec {
+	r, err := params.resolve(p.length(), forward)
+	if err != nil {
+		if params.noPanic {
+			return nil
+		}
+		panic(err)
+	}
+
+	return r
+}
+
+// rangeFirst : returns the first element of the range and its index, nil if the range is empty.
+// The list must be locked by caller.
+func (p *XList[T]) rangeFirst(r *rangeSpec) (*xlistObj[T], int) {
+	xobj := p.end
+	if r.start != p.length()-1 {
+		xobj = p.goToPosition(r.start)
+	}
+
+	return rangeSeek(r, xobj, r.start, 0)
+}
+
+// rangeSeek : moves from 'xobj' at 'index' by 'n' elements in the direction of the range,
+// then (WithMarkedOnly) by steps of the range up to the first marked element.
+// Returns the element and its index, nil if the range is over. The list must be locked by caller.
+func rangeSeek[T comparable](r *rangeSpec, xobj *xlistObj[T], index, n int) (*xlistObj[T], int) {
+	for xobj != nil {
+		for ; n > 0 && xobj != nil; n-- {
+			xobj = xobj.step(r.forward)
+			if r.forward {
+				index++
+			} else {
+				index--
 			}
+		}
+
+		if xobj == nil || !r.inBounds(index) {
+			return nil, index
+		}
 
-			tmp = tmp.next
-			index++
-			count++
+		if !r.markedOnly || xobj.mark {
+			return xobj, index
 		}
 
+		n = r.step
 	}
+
+	return nil, index
+}
+
+// ----------------------------------------------------------
+
+// All returns a forward iterator over the list.
+// Each element is yielded as (index, T).
+// Options: WithPos/WithEnd/WithCount/WithStep/WithMarkedOnly select the elements,
+// WithLocked/WithSnapshot/WithLive define how the list is locked during the loop.
+// Panics with ErrInvalidIndex/ErrInvalidRange if the range is invalid (see TryAll).
+//
+// Example:
+//
+//	for i, obj := range list.All(xlist.WithPos(2), xlist.WithCount(3)) {
+//		fmt.Println(i, obj)
+//	}
+func (p *XList[T]) All(opt ...func(*RangeOptions)) iter.Seq2[int, T] {
+	return p.iterate(rangeParams(opt), true)
 }
 
 // Backward returns a reverse iterator over the list (from end to start).
 // Each element is yielded as (index, T).
-// Options: WithPos/WithCount can limit the range.
+// Options: WithPos/WithEnd/WithCount/WithStep/WithMarkedOnly select the elements,
+// WithLocked/WithSnapshot/WithLive define how the list is locked during the loop.
+// Panics with ErrInvalidIndex/ErrInvalidRange if the range is invalid (see TryBackward).
 //
 // Example:
 //
@@ -100,62 +289,207 @@ func (p *XList[T]) All(opt ...func(*RangeOptions)) iter.Seq2[int, T] {
 //		fmt.Println(i, obj)
 //	}
 func (p *XList[T]) Backward(opt ...func(*RangeOptions)) iter.Seq2[int, T] {
+	return p.iterate(rangeParams(opt), false)
+}
+
+// TryAll : same as All, but an invalid range is reported with error (ErrInvalidIndex, ErrInvalidRange)
+// instead of panic; the returned iterator is empty then. The range is checked against the current list;
+// if the list changes before the loop and the range becomes invalid, the loop yields nothing.
+//
+// Example:
+//
+//	seq, err := list.TryAll(xlist.WithPos(pos), xlist.WithEnd(end))
+//	if err != nil {
+//		return err
+//	}
+//	for i, obj := range seq {
+//		fmt.Println(i, obj)
+//	}
+func (p *XList[T]) TryAll(opt ...func(*RangeOptions)) (iter.Seq2[int, T], error) {
+	return p.tryIterate(rangeParams(opt), true)
+}
+
+// TryBackward : same as Backward, but an invalid range is reported with error instead of panic (see TryAll).
+func (p *XList[T]) TryBackward(opt ...func(*RangeOptions)) (iter.Seq2[int, T], error) {
+	return p.tryIterate(rangeParams(opt), false)
+}
+
+// tryIterate : checks the range, returns iterator that never panics because of the range
+func (p *XList[T]) tryIterate(params *RangeOptions, forward direction) (iter.Seq2[int, T], error) {
+	params.noPanic = true
+
+	if _, err := params.resolve(p.length(), forward); err != nil {
+		return func(func(int, T) bool) {}, err
+	}
+
+	return p.iterate(params, forward), nil
+}
+
+// iterate : returns iterator according to the iteration mode
+func (p *XList[T]) iterate(params *RangeOptions, forward direction) iter.Seq2[int, T] {
+	switch params.mode {
+	case iterSnapshot:
+		return p.iterateSnapshot(params, forward)
+	case iterLive:
+		return p.iterateLive(params, forward)
+	default:
+		return p.iterateLocked(params, forward)
+	}
+}
+
+// step : returns neighbour object in the direction of iteration
+func (xobj *xlistObj[T]) step(forward direction) *xlistObj[T] {
+	if forward {
+		return xobj.next
+	}
+	return xobj.prev
+}
+
+// iterateLocked : the whole loop runs under read lock.
+// The lock and reentrancy check keep the list unchanged during the loop,
+// the modification counters are checked as a safety net.
+// A loop pulled with iter.Pull yields a copy of the range (see runLoop).
+func (p *XList[T]) iterateLocked(params *RangeOptions, forward direction) iter.Seq2[int, T] {
 	return func(yield func(int, T) bool) {
-		var tmp *xlistObj[T]
-		var xobjList []*xlistObj[T]
+		p.runLoop(yield, func(yield func(int, T) bool) {
+			r := p.rangeOf(params, forward)
+			if r == nil {
+				return
+			}
 
-		params := &RangeOptions{index: -1}
+			xobj, index := p.rangeFirst(r)
+			modCount, valCount := p.modCount, p.valCount
 
-		p.mtx.RLock()
-		defer p.mtx.RUnlock()
+			for count := 0; xobj != nil && (count < r.limit || r.limit == 0); count++ {
+				if !yield(index, *xobj.obj) {
+					return
+				}
 
-		tmp = p.end
-		index := p.size - 1
-		count := p.size
+				if p.modifiedSince(modCount, valCount, params.allowValues) {
+					panic(ErrConcurrentModification)
+				}
 
-		if len(opt) > 0 {
-			for _, optSet := range opt {
-				optSet(params)
+				xobj, index = rangeSeek(r, xobj, index, r.step)
 			}
+		})
+	}
+}
 
-			if params.index == -1 { // if no index defined
-				params.index = p.size - 1
+// iterateSnapshot : copies the range under read lock, the loop runs without lock
+func (p *XList[T]) iterateSnapshot(params *RangeOptions, forward direction) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		var indexes []int
+		var values []T
+
+		func() {
+			defer p.runlock(p.rlock())
+
+			r := p.rangeOf(params, forward)
+			if r == nil {
+				return
 			}
 
-			// Validate index bounds (after -1 handling)
-			if params.index < 0 || params.index >= p.size {
-				panic(fmt.Sprintf("%v: index=%d, xlist size size=%d", ErrInvalidIndex, params.index, p.size))
+			xobj, index := p.rangeFirst(r)
+
+			for count := 0; xobj != nil && (count < r.limit || r.limit == 0); count++ {
+				indexes = append(indexes, index)
+				values = append(values, *xobj.obj)
+
+				xobj, index = rangeSeek(r, xobj, index, r.step)
 			}
+		}()
 
-			// avoid negative indexes
-			if params.count < 0 {
-				params.count = -1 * params.count
+		for i, value := range values {
+			if !yield(indexes[i], value) {
+				return
 			}
+		}
+	}
+}
 
-			// avoid over range with Count
-			if (params.index + 1 - params.count) < 0 {
-				params.count = params.index + 1 // ??? Проверить +/- 1
+// iterateLive : read lock is taken for each step, the loop runs without lock
+func (p *XList[T]) iterateLive(params *RangeOptions, forward direction) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		var r *rangeSpec
+		var xobj *xlistObj[T]
+		var index int
+		var modCount, valCount uint64
+
+		// next : moves cursor to the next element, returns its value
+		next := func(first bool) (T, bool) {
+			defer p.runlock(p.rlock())
+
+			if first {
+				if r = p.rangeOf(params, forward); r == nil {
+					var zero T
+					return zero, false
+				}
+				// the list may change during the loop: without WithEnd it goes on up to the end of the chain
+				if !params.hasEnd {
+					r.unbound()
+				}
+				xobj, index = p.rangeFirst(r)
+			} else {
+				if params.failFast && p.modifiedSince(modCount, valCount, params.allowValues) {
+					panic(ErrConcurrentModification)
+				}
+				xobj, index = p.liveStep(xobj, index, modCount, forward)
+				xobj, index = rangeSeek(r, xobj, index, r.step-1)
 			}
+			modCount, valCount = p.modCount, p.valCount
 
-			xobjList = p.getObjectsAt(params.index)
-			if len(xobjList) > 0 {
-				tmp = xobjList[0]
-				index = params.index
-				count = params.count
+			if xobj == nil {
+				var zero T
+				return zero, false
 			}
+
+			return *xobj.obj, true
 		}
 
-		for tmp != nil && (count > 0 /*count < params.count*/ || params.count == 0) {
-			if !yield(index, *tmp.obj) {
+		for count := 0; r == nil || count < r.limit || r.limit == 0; count++ {
+			value, ok := next(count == 0)
+			if !ok || !yield(index, value) {
 				return
 			}
+		}
+	}
+}
+
+// modifiedSince : checks modification counters against the ones seen before.
+// Value modifications are ignored if 'allowValues' is set. The list must be locked by caller.
+func (p *XList[T]) modifiedSince(modCount, valCount uint64, allowValues bool) bool {
+	return p.modCount != modCount || (!allowValues && p.valCount != valCount)
+}
 
-			tmp = tmp.prev
-			index--
-			count--
+// liveStep : moves live cursor from 'xobj' to the next element in the direction of iteration.
+// 'modCount' is the structural modification counter seen at the previous step.
+// Returns the next object and its index, nil if there is no next element. The list must be locked by caller.
+func (p *XList[T]) liveStep(xobj *xlistObj[T], index int, modCount uint64, forward direction) (*xlistObj[T], int) {
+	next := xobj.step(forward)
+
+	if p.modCount == modCount { // no structural changes: just step
+		if forward {
+			return next, index + 1
 		}
+		return next, index - 1
+	}
 
+	// Deleted objects keep links to their former neighbours, skip the deleted ones
+	for next != nil && next.removed {
+		next = next.step(forward)
 	}
+
+	if next == nil {
+		return nil, 0
+	}
+
+	// Indexes could shift, and the chain could be cleared or moved out of the list
+	index = p.indexOfObj(next)
+	if index < 0 {
+		return nil, 0
+	}
+
+	return next, index
 }
 
 // Values returns a forward iterator of values only (without indices).
@@ -191,6 +525,12 @@ func (p *XList[T]) ValuesBackward(opt ...func(*RangeOptions)) iter.Seq[T] {
 //	}
 func ToValues[T any](seq2 iter.Seq2[int, T]) iter.Seq[T] {
 	return func(yield func(T) bool) {
+		if pulled(yield) {
+			// the loop of 'seq2' has to know it's pulled as well
+			seq2(pulledValues(yield))
+			return
+		}
+
 		for _, value := range seq2 {
 			if !yield(value) {
 				return
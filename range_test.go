@@ -0,0 +1,233 @@
+package xlist
+
+import (
+	"fmt"
+	"iter"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Spec of RangeOptions: every case runs through all range iterators
+// (locked, snapshot, live, mutable and Try* variants) with the same expected result.
+
+// rangeSpecMarked : marked indexes of the test list
+var rangeSpecMarked = []int{1, 2, 5, 8}
+
+type rangeSpecCase struct {
+	name     string
+	empty    bool // empty list instead of 0..9
+	backward bool
+	opts     []func(*RangeOptions)
+	want     []int // expected indexes
+	err      error
+}
+
+var rangeSpecCases = []rangeSpecCase{
+	// forward
+	{name: "no options", want: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+	{name: "pos", opts: rangeOpts(WithPos(3)), want: []int{3, 4, 5, 6, 7, 8, 9}},
+	{name: "pos last", opts: rangeOpts(WithPos(9)), want: []int{9}},
+	{name: "pos at the end", opts: rangeOpts(WithPos(10)), want: nil},
+	{name: "pos after the end", opts: rangeOpts(WithPos(11)), err: ErrInvalidIndex},
+	{name: "negative pos", opts: rangeOpts(WithPos(-1)), err: ErrInvalidIndex},
+	{name: "count", opts: rangeOpts(WithCount(3)), want: []int{0, 1, 2}},
+	{name: "negative count", opts: rangeOpts(WithCount(-3)), want: []int{0, 1, 2}},
+	{name: "count clamped", opts: rangeOpts(WithPos(8), WithCount(5)), want: []int{8, 9}},
+	{name: "end", opts: rangeOpts(WithEnd(4)), want: []int{0, 1, 2, 3}},
+	{name: "pos and end", opts: rangeOpts(WithPos(2), WithEnd(5)), want: []int{2, 3, 4}},
+	{name: "end at pos", opts: rangeOpts(WithPos(2), WithEnd(2)), want: nil},
+	{name: "end before pos", opts: rangeOpts(WithPos(5), WithEnd(2)), err: ErrInvalidRange},
+	{name: "end clamped", opts: rangeOpts(WithPos(7), WithEnd(20)), want: []int{7, 8, 9}},
+	{name: "negative end", opts: rangeOpts(WithEnd(-5)), err: ErrInvalidRange},
+	{name: "end and count", opts: rangeOpts(WithEnd(5), WithCount(2)), want: []int{0, 1}},
+	{name: "step", opts: rangeOpts(WithStep(3)), want: []int{0, 3, 6, 9}},
+	{name: "pos and step", opts: rangeOpts(WithPos(1), WithStep(4)), want: []int{1, 5, 9}},
+	{name: "pos, end and step", opts: rangeOpts(WithPos(1), WithEnd(9), WithStep(4)), want: []int{1, 5}},
+	{name: "step and count", opts: rangeOpts(WithStep(2), WithCount(2)), want: []int{0, 2}},
+	{name: "zero step", opts: rangeOpts(WithStep(0)), err: ErrInvalidRange},
+	{name: "negative step", opts: rangeOpts(WithStep(-2)), err: ErrInvalidRange},
+	{name: "marked", opts: rangeOpts(WithMarkedOnly()), want: []int{1, 2, 5, 8}},
+	{name: "marked and step", opts: rangeOpts(WithMarkedOnly(), WithStep(2)), want: []int{2, 8}},
+	{name: "marked, pos and step", opts: rangeOpts(WithMarkedOnly(), WithPos(1), WithStep(3)), want: []int{1}},
+	{name: "marked, pos and end", opts: rangeOpts(WithMarkedOnly(), WithPos(3), WithEnd(8)), want: []int{5}},
+	{name: "marked and count", opts: rangeOpts(WithMarkedOnly(), WithCount(2)), want: []int{1, 2}},
+
+	// backward
+	{name: "backward", backward: true, want: []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}},
+	{name: "backward pos", backward: true, opts: rangeOpts(WithPos(3)), want: []int{3, 2, 1, 0}},
+	{name: "backward pos first", backward: true, opts: rangeOpts(WithPos(0)), want: []int{0}},
+	{name: "backward pos at the end", backward: true, opts: rangeOpts(WithPos(10)), want: nil},
+	{name: "backward pos after the end", backward: true, opts: rangeOpts(WithPos(11)), err: ErrInvalidIndex},
+	{name: "backward negative pos", backward: true, opts: rangeOpts(WithPos(-1)), err: ErrInvalidIndex},
+	{name: "backward count", backward: true, opts: rangeOpts(WithCount(3)), want: []int{9, 8, 7}},
+	{name: "backward count clamped", backward: true, opts: rangeOpts(WithPos(1), WithCount(5)), want: []int{1, 0}},
+	{name: "backward end", backward: true, opts: rangeOpts(WithEnd(6)), want: []int{9, 8, 7}},
+	{name: "backward pos and end", backward: true, opts: rangeOpts(WithPos(5), WithEnd(2)), want: []int{5, 4, 3}},
+	{name: "backward end at pos", backward: true, opts: rangeOpts(WithPos(4), WithEnd(4)), want: nil},
+	{name: "backward end after pos", backward: true, opts: rangeOpts(WithPos(2), WithEnd(5)), err: ErrInvalidRange},
+	{name: "backward end clamped", backward: true, opts: rangeOpts(WithPos(2), WithEnd(-10)), want: []int{2, 1, 0}},
+	{name: "backward step", backward: true, opts: rangeOpts(WithStep(4)), want: []int{9, 5, 1}},
+	{name: "backward step and end", backward: true, opts: rangeOpts(WithStep(4), WithEnd(1)), want: []int{9, 5}},
+	{name: "backward zero step", backward: true, opts: rangeOpts(WithStep(0)), err: ErrInvalidRange},
+	{name: "backward marked", backward: true, opts: rangeOpts(WithMarkedOnly()), want: []int{8, 5, 2, 1}},
+	{name: "backward marked and step", backward: true, opts: rangeOpts(WithMarkedOnly(), WithPos(8), WithStep(3)), want: []int{8, 5, 2}},
+	{name: "backward marked and count", backward: true, opts: rangeOpts(WithMarkedOnly(), WithPos(7), WithCount(1)), want: []int{5}},
+
+	// empty list
+	{name: "empty", empty: true, want: nil},
+	{name: "empty count", empty: true, opts: rangeOpts(WithCount(3)), want: nil},
+	{name: "empty end", empty: true, opts: rangeOpts(WithEnd(3)), want: nil},
+	{name: "empty step", empty: true, opts: rangeOpts(WithStep(2)), want: nil},
+	{name: "empty pos", empty: true, opts: rangeOpts(WithPos(0)), want: nil},
+	{name: "empty backward", empty: true, backward: true, want: nil},
+	{name: "empty backward count", empty: true, backward: true, opts: rangeOpts(WithCount(2)), want: nil},
+	{name: "empty backward pos", empty: true, backward: true, opts: rangeOpts(WithPos(0)), want: nil},
+}
+
+// rangeOpts : helper for the table
+func rangeOpts(opt ...func(*RangeOptions)) []func(*RangeOptions) {
+	return opt
+}
+
+// newRangeSpecList : list of values 0..9 (value = index) with marked elements
+func newRangeSpecList(empty bool) *XList[int] {
+	list := New[int]()
+	if empty {
+		return list
+	}
+
+	for i := range 10 {
+		list.Append(i)
+	}
+	for _, i := range rangeSpecMarked {
+		list.MarkAtIndex(i)
+	}
+
+	return list
+}
+
+// collectIndexes : runs the iterator, checks that value = index and returns the indexes
+func collectIndexes(t *testing.T, seq iter.Seq2[int, int]) []int {
+	t.Helper()
+
+	var indexes []int
+	for i, v := range seq {
+		assert.Equal(t, i, v)
+		indexes = append(indexes, i)
+	}
+
+	return indexes
+}
+
+func TestRangeOptionsSpec(t *testing.T) {
+	for _, tc := range rangeSpecCases {
+		t.Run(tc.name, func(t *testing.T) {
+			list := newRangeSpecList(tc.empty)
+
+			iterate, try, iterateMut := list.All, list.TryAll, list.AllMut
+			if tc.backward {
+				iterate, try, iterateMut = list.Backward, list.TryBackward, list.BackwardMut
+			}
+
+			seq, err := try(tc.opts...)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Nil(t, collectIndexes(t, seq))
+
+				assert.Panics(t, func() {
+					for range iterate(tc.opts...) {
+					}
+				})
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, collectIndexes(t, seq))
+
+			for _, mode := range []func(*RangeOptions){WithLocked(), WithSnapshot(), WithLive()} {
+				assert.Equal(t, tc.want, collectIndexes(t, iterate(append(rangeOpts(mode), tc.opts...)...)))
+			}
+
+			var indexes []int
+			for i, ref := range iterateMut(tc.opts...) {
+				assert.Equal(t, i, ref.Get())
+				indexes = append(indexes, i)
+			}
+			assert.Equal(t, tc.want, indexes)
+		})
+	}
+}
+
+// The range is checked at the call of TryAll; a range broken later gives empty loop instead of panic
+func TestTryAllShrunkList(t *testing.T) {
+	list := newRangeSpecList(false)
+
+	seq, err := list.TryAll(WithPos(8))
+	assert.Nil(t, err)
+
+	list.Set(1, 2, 3)
+	assert.Nil(t, collectIndexes(t, seq))
+
+	seq, err = list.TryBackward(WithStep(0))
+	assert.ErrorIs(t, err, ErrInvalidRange)
+	assert.Equal(t, fmt.Sprintf("%v: step=0", ErrInvalidRange), err.Error())
+	assert.Nil(t, collectIndexes(t, seq))
+}
+
+// AllMut keeps WithEnd on the same elements when elements are deleted or inserted
+func TestAllMutRangeShift(t *testing.T) {
+	list := newRangeSpecList(false)
+
+	var got []int
+	for _, ref := range list.AllMut(WithPos(2), WithEnd(7), WithStep(2)) {
+		v := ref.Get()
+		got = append(got, v)
+
+		switch v {
+		case 2:
+			_ = ref.InsertAfter(100, 101, 102)
+		case 4:
+			_ = ref.Delete()
+		}
+	}
+
+	// inserted elements are not visited, step 2 goes over original elements 2..6
+	assert.Equal(t, []int{2, 4, 6}, got)
+	assert.Equal(t, []int{0, 1, 2, 100, 101, 102, 3, 5, 6, 7, 8, 9}, slicesOf(list))
+}
+
+// The live mode follows the list as it is at each step: the range is not cut at the size seen at the start
+func TestLiveRangeFollowsList(t *testing.T) {
+	list := New[int](1, 2, 3)
+
+	var got []int
+	for _, v := range list.All(WithLive()) {
+		got = append(got, v)
+		if v == 1 {
+			_ = list.Insert(0, 0)
+		}
+	}
+	assert.Equal(t, []int{1, 2, 3}, got)
+
+	list = New[int](1, 2, 3)
+	got = nil
+	for _, v := range list.All(WithLive()) {
+		got = append(got, v)
+		if v == 2 {
+			list.Append(4)
+		}
+	}
+	assert.Equal(t, []int{1, 2, 3, 4}, got)
+
+	// WithEnd bounds actual positions
+	list = New[int](1, 2, 3, 4)
+	got = nil
+	for _, v := range list.All(WithLive(), WithEnd(3)) {
+		got = append(got, v)
+		if v == 1 {
+			_ = list.Insert(0, 0)
+		}
+	}
+	assert.Equal(t, []int{1, 2}, got)
+}
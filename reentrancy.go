@@ -0,0 +1,286 @@
+// reentrancy.go
+// Detection of calls into the list from its own callbacks
+// Created by Vokhmin D.A. 10.2026
+
+package xlist
+
+import (
+	"errors"
+	"runtime"
+)
+
+// A callback (Find, Modify, body of `range list.All()` loop, ...) runs while the list is locked.
+// Any locking call into the same list from such callback would deadlock on 'mtx', so:
+//   - reads run without locking: the goroutine already holds the lock;
+//   - mutations panic (or return) ErrReentrantCall, or, with WithDeferredReentrancy option,
+//     are queued (return ErrDeferred) and applied once the outer operation releases the lock;
+//     their errors go to the handler of WithDeferredErrorHandler.
+//
+// The goroutine that runs callbacks is locked to its OS thread for that time, so it's told apart
+// from other goroutines by the thread id (see callerID), taken once per callback scope.
+// The scopes are looked up only when a call can't take the lock at once: a lock that can be taken
+// is not held by a scope of the current goroutine. So calls out of callbacks make no syscalls
+// while the list is free (or only read locked, for reads).
+// A coroutine of iter.Pull can't switch with its thread locked, so loops pulled with iter.Pull
+// don't run their body as callbacks (see runLoop and pulled).
+// Unsynchronized lists are used by one goroutine, so they don't need the id at all.
+
+// callbackState : state of a goroutine that runs callbacks of the list
+type callbackState struct {
+	id      int64          // caller id (0 for unsynchronized lists)
+	depth   int            // nesting of callback scopes
+	pending []func() error // deferred mutations
+}
+
+// runCallbacks : runs 'fn' under the lock of the list (write or read lock) with the current
+// goroutine marked as running callbacks of the list.
+// Deferred mutations are applied after the lock is released.
+// When 'fn' panics the deferred mutations are dropped and the panic goes on as is.
+func (p *XList[T]) runCallbacks(write bool, fn func()) {
+	var pending []func() error
+	done := false
+	defer func() {
+		if done {
+			p.applyDeferred(pending)
+		}
+	}()
+
+	if write {
+		p.lock()
+		defer p.unlock()
+	} else {
+		defer p.runlock(p.rlock())
+	}
+
+	state := p.enterCallbacks()
+	defer func() {
+		pending = p.leaveCallbacks(state)
+	}()
+
+	fn()
+	done = true
+}
+
+// runLoop : runs read locked range loop 'loop' of the list with 'yield' as its body.
+// Usually the body runs as callbacks of the list (see runCallbacks). The body of a loop pulled
+// with iter.Pull (see pulled) runs in the goroutine that pulls, outside of the callback scope,
+// and the coroutine of the loop can't keep its thread locked between yields; such loop copies
+// its elements under the lock and yields them after the lock is released (like WithSnapshot).
+func (p *XList[T]) runLoop(yield func(int, T) bool, loop func(yield func(int, T) bool)) {
+	if !pulled(yield) {
+		p.runCallbacks(false, func() {
+			loop(yield)
+		})
+		return
+	}
+
+	var (
+		indexes []int
+		values  []T
+	)
+
+	p.runCallbacks(false, func() {
+		loop(func(index int, value T) bool {
+			indexes = append(indexes, index)
+			values = append(values, value)
+			return true
+		})
+	})
+
+	for i, value := range values {
+		if !yield(indexes[i], value) {
+			return
+		}
+	}
+}
+
+// runCallbacksPair : same as runCallbacks(false) for two lists read locked together (see rlockPair):
+// the current goroutine runs callbacks of both lists.
+func (p *XList[T]) runCallbacksPair(other *XList[T], fn func()) {
+	var pending, otherPending []func() error
+	done := false
+	defer func() {
+		if done {
+			p.applyDeferred(pending)
+			other.applyDeferred(otherPending)
+		}
+	}()
+
+	unlock := rlockPair(p, other)
+	defer unlock()
+
+	state := p.enterCallbacks()
+	defer func() {
+		pending = p.leaveCallbacks(state)
+	}()
+
+	if other != p {
+		otherState := other.enterCallbacks()
+		defer func() {
+			otherPending = other.leaveCallbacks(otherState)
+		}()
+	}
+
+	fn()
+	done = true
+}
+
+// enterCallbacks : marks the current goroutine as running callbacks of the list
+// (locks it to its OS thread until leaveCallbacks)
+func (p *XList[T]) enterCallbacks() *callbackState {
+	var id int64
+	if !p.opts.unsync {
+		runtime.LockOSThread()
+		id = callerID()
+	}
+
+	p.cbMtx.Lock()
+	defer p.cbMtx.Unlock()
+
+	state := p.callbackStateOf(id)
+	if state == nil {
+		state = &callbackState{id: id}
+		p.cbStates = append(p.cbStates, state)
+		p.cbActive.Add(1)
+	}
+
+	state.depth++
+
+	return state
+}
+
+// leaveCallbacks : ends callback scope of the current goroutine,
+// returns deferred mutations when the outermost scope is left.
+func (p *XList[T]) leaveCallbacks(state *callbackState) []func() error {
+	if !p.opts.unsync {
+		defer runtime.UnlockOSThread()
+	}
+
+	p.cbMtx.Lock()
+	defer p.cbMtx.Unlock()
+
+	state.depth--
+	if state.depth > 0 {
+		return nil
+	}
+
+	for i, st := range p.cbStates {
+		if st == state {
+			last := len(p.cbStates) - 1
+			p.cbStates[i] = p.cbStates[last]
+			p.cbStates[last] = nil
+			p.cbStates = p.cbStates[:last]
+			break
+		}
+	}
+	p.cbActive.Add(-1)
+
+	return state.pending
+}
+
+// callbackStateOf : returns callback state of caller 'id' or nil (cbMtx must be locked)
+func (p *XList[T]) callbackStateOf(id int64) *callbackState {
+	for _, state := range p.cbStates {
+		if state.id == id {
+			return state
+		}
+	}
+
+	return nil
+}
+
+// currentCallbackState : returns callback state of the current goroutine or nil
+func (p *XList[T]) currentCallbackState() *callbackState {
+	if p.cbActive.Load() == 0 {
+		return nil
+	}
+
+	var id int64
+	if !p.opts.unsync {
+		// the thread is locked until the comparison is done: otherwise the goroutine could move
+		// to another thread and its id could be taken by a goroutine that enters callbacks meanwhile
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+
+		id = callerID()
+	}
+
+	p.cbMtx.Lock()
+	defer p.cbMtx.Unlock()
+
+	return p.callbackStateOf(id)
+}
+
+// reentrant : returns 'true' if the current goroutine runs a callback of the list.
+// Public methods call it once, before they lock the list.
+func (p *XList[T]) reentrant() bool {
+	if p.cbActive.Load() == 0 {
+		return false
+	}
+
+	// callback scopes hold the lock: if it can be taken, the current goroutine is not in a scope
+	if !p.opts.unsync && p.mtx.TryLock() {
+		p.mtx.Unlock()
+		return false
+	}
+
+	return p.currentCallbackState() != nil
+}
+
+// deferCall : handles a mutation called from inside a callback of the list.
+// Queues 'fn' and returns ErrDeferred if the list was created with WithDeferredReentrancy,
+// otherwise returns ErrReentrantCall.
+func (p *XList[T]) deferCall(fn func() error) error {
+	if !p.opts.deferReentrant {
+		return ErrReentrantCall
+	}
+
+	state := p.currentCallbackState()
+	if state == nil {
+		return ErrReentrantCall
+	}
+
+	// workers of parallel operations on an unsynchronized list share its state (id 0)
+	p.cbMtx.Lock()
+	state.pending = append(state.pending, fn)
+	p.cbMtx.Unlock()
+
+	return ErrDeferred
+}
+
+// deferAction : same as deferCall for mutations without error result,
+// returns nil when 'fn' is queued (such mutations have no way to report ErrDeferred).
+func (p *XList[T]) deferAction(fn func()) error {
+	err := p.deferCall(func() error {
+		fn()
+		return nil
+	})
+	if errors.Is(err, ErrDeferred) {
+		return nil
+	}
+
+	return err
+}
+
+// applyDeferred : applies deferred mutations in order once the lock is released.
+// Errors of the mutations are passed to the handler of WithDeferredErrorHandler;
+// without handler they panic (joined) after all the mutations are applied.
+func (p *XList[T]) applyDeferred(pending []func() error) {
+	var errs []error
+	for _, mutation := range pending {
+		err := mutation()
+		if err == nil || errors.Is(err, ErrDeferred) {
+			continue
+		}
+
+		if p.opts.onDeferredError != nil {
+			p.opts.onDeferredError(err)
+			continue
+		}
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		panic(errors.Join(errs...))
+	}
+}
@@ -0,0 +1,331 @@
+// scope.go
+// Range-scoped bulk, marking, sort and delete operations
+// Created by Vokhmin D.A. 10.2026
+
+package xlist
+
+// Scope : range of elements [from, to] of the list for bulk operations, see XList.Range.
+// The range is given by indexes and resolved by each operation under the lock of the list:
+// the endpoints are located once (from the nearer end of the list), then the operation
+// walks the range only. An invalid range gives ErrInvalidIndex.
+type Scope[T comparable] struct {
+	list     *XList[T]
+	from, to int
+}
+
+// Range : returns Scope of elements [from, to] (indexes are inclusive, like in CopyRange);
+// 'to' = -1 means the last element. The whole range (0, -1) of an empty list is valid and empty.
+//
+// Example:
+//
+//	list.Range(10, 19).Sort(func(a, b int) bool { return a < b }) // sort the second ten
+//	n, err := list.Range(100, -1).Delete()                         // truncate to 100 elements
+func (p *XList[T]) Range(from, to int) *Scope[T] {
+	return &Scope[T]{list: p, from: from, to: to}
+}
+
+// Reverse : reverses order of values of the list.
+// Values move between elements like in Sort: marks stay in place.
+func (p *XList[T]) Reverse() {
+	if p.reentrant() {
+		if err := p.deferAction(func() { p.Reverse() }); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	p.lock()
+	defer p.unlock()
+
+	p.reverse(p.home, p.end, p.length())
+}
+
+// Find : same as XList.Find for elements of the range; 'index' is the index in the list
+func (s *Scope[T]) Find(is func(index int, object T) bool) (*XList[T], error) {
+	p := s.list
+	newList := newTemp[T]()
+	var err error
+
+	p.runCallbacks(false, func() {
+		var first *xlistObj[T]
+		var n int
+		if first, _, n, err = s.bounds(); err != nil {
+			return
+		}
+
+		xobj := first
+		for i := range n {
+			if is(s.from+i, *xobj.obj) {
+				newList.append(*xobj.obj)
+			}
+			xobj = xobj.next
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return p.adopt(newList), nil
+}
+
+// Modify : same as XList.Modify for elements of the range; 'index' is the index in the list
+func (s *Scope[T]) Modify(change func(index int, object T) T) error {
+	p := s.list
+	if p.reentrant() {
+		return p.deferCall(func() error { return s.Modify(change) })
+	}
+
+	var err error
+
+	p.runCallbacks(true, func() {
+		var first *xlistObj[T]
+		var n int
+		if first, _, n, err = s.bounds(); err != nil {
+			return
+		}
+		p.valueChanged(nil)
+
+		xobj := first
+		for i := range n {
+			*xobj.obj = change(s.from+i, *xobj.obj)
+			xobj = xobj.next
+		}
+	})
+
+	return err
+}
+
+// ModifyRev : same as XList.ModifyRev for elements of the range (from the last one to the first one)
+func (s *Scope[T]) ModifyRev(change func(index int, object T) T) error {
+	p := s.list
+	if p.reentrant() {
+		return p.deferCall(func() error { return s.ModifyRev(change) })
+	}
+
+	var err error
+
+	p.runCallbacks(true, func() {
+		var last *xlistObj[T]
+		var n int
+		if _, last, n, err = s.bounds(); err != nil {
+			return
+		}
+		p.valueChanged(nil)
+
+		xobj := last
+		for i := n - 1; i >= 0; i-- {
+			*xobj.obj = change(s.from+i, *xobj.obj)
+			xobj = xobj.prev
+		}
+	})
+
+	return err
+}
+
+// MarkAll : marks all elements of the range
+func (s *Scope[T]) MarkAll() error {
+	return s.setMarks(true)
+}
+
+// UnmarkAll : clears marks of all elements of the range
+func (s *Scope[T]) UnmarkAll() error {
+	return s.setMarks(false)
+}
+
+// setMarks : sets marks of all elements of the range to 'mark'
+func (s *Scope[T]) setMarks(mark bool) error {
+	p := s.list
+	if p.reentrant() {
+		return p.deferCall(func() error { return s.setMarks(mark) })
+	}
+
+	p.lock()
+	defer p.unlock()
+
+	first, _, n, err := s.bounds()
+	if err != nil {
+		return err
+	}
+
+	xobj := first
+	for range n {
+		xobj.mark = mark
+		xobj = xobj.next
+	}
+
+	return nil
+}
+
+// Contains : checks whether all 'objects' are in the range (see XList.Contains)
+func (s *Scope[T]) Contains(objects ...T) (bool, error) {
+	return s.contains(false, objects)
+}
+
+// ContainsSome : checks whether any of 'objects' is in the range (see XList.ContainsSome)
+func (s *Scope[T]) ContainsSome(objects ...T) (bool, error) {
+	return s.contains(true, objects)
+}
+
+// contains : internal realisation of Contains and ContainsSome
+func (s *Scope[T]) contains(some bool, objects []T) (bool, error) {
+	p := s.list
+
+	defer p.runlock(p.rlock())
+
+	first, _, n, err := s.bounds()
+	if err != nil {
+		return false, err
+	}
+
+	lookingFor := make(map[T]struct{}, len(objects))
+	for _, obj := range objects {
+		lookingFor[obj] = struct{}{}
+	}
+	if len(lookingFor) == 0 {
+		return !some, nil
+	}
+
+	xobj := first
+	for range n {
+		if _, found := lookingFor[*xobj.obj]; found {
+			if some {
+				return true, nil
+			}
+
+			delete(lookingFor, *xobj.obj)
+			if len(lookingFor) == 0 {
+				return true, nil
+			}
+		}
+		xobj = xobj.next
+	}
+
+	return false, nil
+}
+
+// Sort : sorts elements of the range with PDQSort (see XList.PDQSort); elements outside the range stay in place
+func (s *Scope[T]) Sort(compare func(a, b T) bool) error {
+	p := s.list
+	if p.reentrant() {
+		return p.deferCall(func() error { return s.Sort(compare) })
+	}
+
+	p.lock()
+	defer p.unlock()
+
+	first, last, n, err := s.bounds()
+	if err != nil {
+		return err
+	}
+
+	p.pdqSort(first, last, n, compare)
+
+	return nil
+}
+
+// Reverse : reverses order of values of the range (see XList.Reverse)
+func (s *Scope[T]) Reverse() error {
+	p := s.list
+	if p.reentrant() {
+		return p.deferCall(func() error { return s.Reverse() })
+	}
+
+	p.lock()
+	defer p.unlock()
+
+	first, last, n, err := s.bounds()
+	if err != nil {
+		return err
+	}
+
+	p.reverse(first, last, n)
+
+	return nil
+}
+
+// Delete : deletes elements of the range, returns their number
+func (s *Scope[T]) Delete() (int, error) {
+	p := s.list
+	if p.reentrant() {
+		return 0, p.deferCall(func() error { _, err := s.Delete(); return err })
+	}
+
+	p.lock()
+	defer p.unlock()
+
+	first, _, n, err := s.bounds()
+	if err != nil {
+		return 0, err
+	}
+
+	xobj := first
+	for range n {
+		next := xobj.next
+		p.unlink(xobj)
+		xobj = next
+	}
+
+	return n, nil
+}
+
+// bounds : resolves the range, returns its first and last objects and number of elements
+// (internal: list is locked by caller)
+func (s *Scope[T]) bounds() (*xlistObj[T], *xlistObj[T], int, error) {
+	p := s.list
+	size := p.length()
+
+	from, to := s.from, s.to
+	if to == -1 {
+		if from == 0 && size == 0 {
+			return nil, nil, 0, nil
+		}
+		to = size - 1
+	}
+
+	if from < 0 || from > size-1 || to < 0 || to > size-1 || from > to {
+		return nil, nil, 0, ErrInvalidIndex
+	}
+
+	first := p.objectAt(from, nil, 0)
+	last := p.objectAt(to, first, from)
+
+	return first, last, to - from + 1, nil
+}
+
+// objectAt : returns object at valid index 'pos' walking from the nearest of home, end and 'known'
+// (object at index 'knownPos' before 'pos', or nil) (internal: list is locked by caller)
+func (p *XList[T]) objectAt(pos int, known *xlistObj[T], knownPos int) *xlistObj[T] {
+	size := p.length()
+
+	if known == nil {
+		known, knownPos = p.home, 0
+	}
+
+	if size-1-pos < pos-knownPos {
+		xobj := p.end
+		for range size - 1 - pos {
+			xobj = xobj.prev
+		}
+		return xobj
+	}
+
+	xobj := known
+	for range pos - knownPos {
+		xobj = xobj.next
+	}
+	return xobj
+}
+
+// reverse : reverses values of segment [first..last] of 'n' elements (internal: list is locked by caller)
+func (p *XList[T]) reverse(first, last *xlistObj[T], n int) {
+	if n < 2 {
+		return
+	}
+	p.valueChanged(nil)
+
+	for range n / 2 {
+		swapObjs(first, last)
+		first, last = first.next, last.prev
+	}
+}
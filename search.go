@@ -0,0 +1,107 @@
+// search.go
+// Search API returning positions: IndexOf, LastIndexOf, IndexFunc, FindFirst, FindLast, FindAllIndexes
+// Created by Vokhmin D.A. 10.2026
+
+package xlist
+
+import (
+	"iter"
+)
+
+// The search methods walk the elements selected by range options, like All
+// (WithPos/WithEnd/WithCount/WithStep/WithMarkedOnly and the iteration modes);
+// LastIndexOf and FindLast walk backward, like Backward, so WithPos is the index to start from.
+// Returned indexes are indexes in the list.
+// WithPos equal to the list size (right after the last element, e.g. the next position after a match
+// at the end) selects nothing: the element is not found. Other invalid ranges are returned as error
+// (ErrInvalidIndex, ErrInvalidRange), like in TryAll.
+// Callbacks run inside the loop, so they follow the reentrancy rules of the iteration mode.
+
+// IndexOf : returns index of the first element equal to 'obj', -1 if there is none.
+// For pointer types pointers are compared, see IndexFunc to compare values.
+//
+// Example:
+//
+//	i, _ := list.IndexOf(42)
+//	j, _ := list.IndexOf(42, xlist.WithPos(i+1)) // the next one
+func (p *XList[T]) IndexOf(obj T, opt ...func(*RangeOptions)) (int, error) {
+	index, _, _, err := p.FindFirst(func(_ int, object T) bool { return object == obj }, opt...)
+	return index, err
+}
+
+// LastIndexOf : returns index of the last element equal to 'obj' (walks from the end), -1 if there is none
+func (p *XList[T]) LastIndexOf(obj T, opt ...func(*RangeOptions)) (int, error) {
+	index, _, _, err := p.FindLast(func(_ int, object T) bool { return object == obj }, opt...)
+	return index, err
+}
+
+// IndexFunc : returns index of the first element satisfying 'is', -1 if there is none
+func (p *XList[T]) IndexFunc(is func(object T) bool, opt ...func(*RangeOptions)) (int, error) {
+	index, _, _, err := p.FindFirst(func(_ int, object T) bool { return is(object) }, opt...)
+	return index, err
+}
+
+// ContainsFunc : checks whether any element satisfies 'is'.
+// Unlike Contains, it can compare values behind pointers.
+//
+// Example:
+//
+//	found, _ := users.ContainsFunc(func(u *User) bool { return u.ID == id })
+func (p *XList[T]) ContainsFunc(is func(object T) bool, opt ...func(*RangeOptions)) (bool, error) {
+	_, _, found, err := p.FindFirst(func(_ int, object T) bool { return is(object) }, opt...)
+	return found, err
+}
+
+// FindFirst : returns index and value of the first element satisfying 'is';
+// 'ok' is false if there is none (index is -1 then)
+func (p *XList[T]) FindFirst(is func(index int, object T) bool, opt ...func(*RangeOptions)) (int, T, bool, error) {
+	return p.findIn(true, is, opt)
+}
+
+// FindLast : returns index and value of the last element satisfying 'is' (walks from the end);
+// 'ok' is false if there is none (index is -1 then)
+func (p *XList[T]) FindLast(is func(index int, object T) bool, opt ...func(*RangeOptions)) (int, T, bool, error) {
+	return p.findIn(false, is, opt)
+}
+
+// FindAllIndexes : returns indexes of all elements satisfying 'is', in ascending order
+func (p *XList[T]) FindAllIndexes(is func(index int, object T) bool, opt ...func(*RangeOptions)) ([]int, error) {
+	seq, err := p.searchRange(true, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	var indexes []int
+
+	for index, object := range seq {
+		if is(index, object) {
+			indexes = append(indexes, index)
+		}
+	}
+
+	return indexes, nil
+}
+
+// findIn : implements FindFirst and FindLast
+func (p *XList[T]) findIn(forward direction, is func(index int, object T) bool, opt []func(*RangeOptions)) (int, T, bool, error) {
+	var zero T
+
+	seq, err := p.searchRange(forward, opt)
+	if err != nil {
+		return -1, zero, false, err
+	}
+
+	for index, object := range seq {
+		if is(index, object) {
+			return index, object, true, nil
+		}
+	}
+
+	return -1, zero, false, nil
+}
+
+// searchRange : returns iterator over the elements selected by range options for the search methods,
+// invalid range gives error.
+func (p *XList[T]) searchRange(forward direction, opt []func(*RangeOptions)) (iter.Seq2[int, T], error) {
+	return p.tryIterate(rangeParams(opt), forward)
+}
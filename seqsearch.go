@@ -0,0 +1,282 @@
+// seqsearch.go
+// Subsequence search: IndexOfSeq, LastIndexOfSeq, CountSeq, ReplaceSeq, SplitOn
+// Created by Vokhmin D.A. 10.2026
+
+package xlist
+
+// Patterns are matched with Knuth-Morris-Pratt algorithm: each operation walks the chain once
+// and compares each element with the pattern O(1) times on average, so it's O(n + m).
+// Occurrences don't overlap (like in the strings package): after a match the search goes on
+// from the element after it. An empty pattern matches before each element and at the end.
+// '...Func' variants compare elements with 'eq' (element of the list, element of the pattern);
+// 'eq' must be an equivalence, since the pattern is compared with itself too. It runs as a callback of the list.
+
+// IndexOfSeq : returns index of the first occurrence of 'pattern', -1 if there is none
+//
+// Example:
+//
+//	tokens := xlist.New[string]("if", "(", "a", ")", "{", "}")
+//	i := tokens.IndexOfSeq(")", "{") // 3
+func (p *XList[T]) IndexOfSeq(pattern ...T) int {
+	return p.indexOfSeq(equal[T], pattern)
+}
+
+// IndexOfSeqFunc : same as IndexOfSeq, comparing elements with 'eq'
+func (p *XList[T]) IndexOfSeqFunc(eq func(a, b T) bool, pattern ...T) int {
+	return p.indexOfSeq(eq, pattern)
+}
+
+// LastIndexOfSeq : returns index of the last occurrence of 'pattern' (walks from the end), -1 if there is none
+func (p *XList[T]) LastIndexOfSeq(pattern ...T) int {
+	return p.lastIndexOfSeq(equal[T], pattern)
+}
+
+// LastIndexOfSeqFunc : same as LastIndexOfSeq, comparing elements with 'eq'
+func (p *XList[T]) LastIndexOfSeqFunc(eq func(a, b T) bool, pattern ...T) int {
+	return p.lastIndexOfSeq(eq, pattern)
+}
+
+// CountSeq : returns number of non-overlapping occurrences of 'pattern' (Size+1 for an empty pattern)
+func (p *XList[T]) CountSeq(pattern ...T) int {
+	return p.countSeq(equal[T], pattern)
+}
+
+// CountSeqFunc : same as CountSeq, comparing elements with 'eq'
+func (p *XList[T]) CountSeqFunc(eq func(a, b T) bool, pattern ...T) int {
+	return p.countSeq(eq, pattern)
+}
+
+// ReplaceSeq : replaces non-overlapping occurrences of 'old' with 'repl', returns number of replacements.
+// Matched elements are deleted and 'repl' elements are inserted in their place (marks of matched elements are lost).
+// An empty 'old' replaces nothing.
+//
+// Example:
+//
+//	events.ReplaceSeq([]string{"down", "up"}, []string{"click"})
+func (p *XList[T]) ReplaceSeq(old, repl []T) int {
+	return p.ReplaceSeqFunc(equal[T], old, repl)
+}
+
+// ReplaceSeqFunc : same as ReplaceSeq, comparing elements with 'eq'
+func (p *XList[T]) ReplaceSeqFunc(eq func(a, b T) bool, old, repl []T) int {
+	if p.reentrant() {
+		if err := p.deferAction(func() { p.ReplaceSeqFunc(eq, old, repl) }); err != nil {
+			panic(err)
+		}
+		return 0
+	}
+
+	if len(old) == 0 {
+		return 0
+	}
+
+	n := 0
+
+	p.runCallbacks(true, func() {
+		m := newSeqMatcher(old, eq)
+
+		for xobj := p.home; xobj != nil; {
+			next := xobj.next
+			if !m.next(*xobj.obj) {
+				xobj = next
+				continue
+			}
+
+			// the match ends at 'xobj': delete it with len(old)-1 elements before it
+			first := xobj
+			for range len(old) - 1 {
+				first = first.prev
+			}
+			for del := first; del != next; {
+				after := del.next
+				p.unlink(del)
+				del = after
+			}
+
+			for _, obj := range repl {
+				p.linkBefore(next, &xlistObj[T]{obj: &obj})
+			}
+
+			n++
+			xobj = next
+		}
+	})
+
+	return n
+}
+
+// SplitOn : splits the list into parts separated by non-overlapping occurrences of 'separator',
+// like strings.Split: n separators give n+1 parts (some of them may be empty), an empty separator
+// gives a part for each element. The list is not changed; the parts are new lists with its options.
+//
+// Example:
+//
+//	lines := stream.SplitOn('\r', '\n')
+func (p *XList[T]) SplitOn(separator ...T) []*XList[T] {
+	return p.splitOn(equal[T], separator)
+}
+
+// SplitOnFunc : same as SplitOn, comparing elements with 'eq'
+func (p *XList[T]) SplitOnFunc(eq func(a, b T) bool, separator ...T) []*XList[T] {
+	return p.splitOn(eq, separator)
+}
+
+// ----------------------------------------------------------
+
+func (p *XList[T]) indexOfSeq(eq func(a, b T) bool, pattern []T) int {
+	if len(pattern) == 0 {
+		return 0
+	}
+
+	found := -1
+
+	p.runCallbacks(false, func() {
+		m := newSeqMatcher(pattern, eq)
+
+		index := 0
+		for xobj := p.home; xobj != nil; xobj = xobj.next {
+			if m.next(*xobj.obj) {
+				found = index - len(pattern) + 1
+				return
+			}
+			index++
+		}
+	})
+
+	return found
+}
+
+func (p *XList[T]) lastIndexOfSeq(eq func(a, b T) bool, pattern []T) int {
+	found := -1
+
+	p.runCallbacks(false, func() {
+		if len(pattern) == 0 {
+			found = p.length()
+			return
+		}
+
+		// backward walk matches the reversed pattern, the match ends at its first element
+		reversed := make([]T, len(pattern))
+		for i, obj := range pattern {
+			reversed[len(pattern)-1-i] = obj
+		}
+		m := newSeqMatcher(reversed, eq)
+
+		index := p.length() - 1
+		for xobj := p.end; xobj != nil; xobj = xobj.prev {
+			if m.next(*xobj.obj) {
+				found = index
+				return
+			}
+			index--
+		}
+	})
+
+	return found
+}
+
+func (p *XList[T]) countSeq(eq func(a, b T) bool, pattern []T) int {
+	n := 0
+
+	p.runCallbacks(false, func() {
+		if len(pattern) == 0 {
+			n = p.length() + 1
+			return
+		}
+
+		m := newSeqMatcher(pattern, eq)
+		for xobj := p.home; xobj != nil; xobj = xobj.next {
+			if m.next(*xobj.obj) {
+				n++
+			}
+		}
+	})
+
+	return n
+}
+
+func (p *XList[T]) splitOn(eq func(a, b T) bool, separator []T) []*XList[T] {
+	var parts []*XList[T]
+
+	p.runCallbacks(false, func() {
+		if len(separator) == 0 {
+			for xobj := p.home; xobj != nil; xobj = xobj.next {
+				part := newTemp[T]()
+				part.append(*xobj.obj)
+				parts = append(parts, p.adopt(part))
+			}
+			return
+		}
+
+		m := newSeqMatcher(separator, eq)
+		part := newTemp[T]()
+
+		for xobj := p.home; xobj != nil; xobj = xobj.next {
+			if !m.next(*xobj.obj) {
+				part.append(*xobj.obj)
+				continue
+			}
+
+			// the part got the separator but its last element
+			for range len(separator) - 1 {
+				part.unlink(part.end)
+			}
+			parts = append(parts, p.adopt(part))
+			part = newTemp[T]()
+		}
+
+		parts = append(parts, p.adopt(part))
+	})
+
+	return parts
+}
+
+// seqMatcher : KMP matcher of a pattern fed element by element
+type seqMatcher[T any] struct {
+	pattern []T
+	eq      func(a, b T) bool
+	border  []int // border[i] : length of the longest proper border of pattern[:i+1]
+	matched int   // length of the matched prefix of the pattern
+}
+
+// newSeqMatcher : returns matcher of non-empty 'pattern'
+func newSeqMatcher[T any](pattern []T, eq func(a, b T) bool) *seqMatcher[T] {
+	border := make([]int, len(pattern))
+
+	k := 0
+	for i := 1; i < len(pattern); i++ {
+		for k > 0 && !eq(pattern[i], pattern[k]) {
+			k = border[k-1]
+		}
+		if eq(pattern[i], pattern[k]) {
+			k++
+		}
+		border[i] = k
+	}
+
+	return &seqMatcher[T]{pattern: pattern, eq: eq, border: border}
+}
+
+// next : feeds 'obj', reports whether an occurrence of the pattern ends at it;
+// the next occurrence doesn't overlap it
+func (m *seqMatcher[T]) next(obj T) bool {
+	for m.matched > 0 && !m.eq(obj, m.pattern[m.matched]) {
+		m.matched = m.border[m.matched-1]
+	}
+
+	if m.eq(obj, m.pattern[m.matched]) {
+		m.matched++
+	}
+
+	if m.matched == len(m.pattern) {
+		m.matched = 0
+		return true
+	}
+
+	return false
+}
+
+// equal : compares elements with ==
+func equal[T comparable](a, b T) bool {
+	return a == b
+}
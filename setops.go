@@ -0,0 +1,304 @@
+// setops.go
+// Set algebra between lists: Union, Intersect, Difference, SymmetricDifference, subsets
+// Created by Vokhmin D.A. 10.2026
+
+package xlist
+
+// Lists are multisets by default: each element counts as many times as it occurs,
+// so an element occurring twice in one list cancels out two occurrences in the other one.
+// With WithDistinct option lists are sets: repeats are ignored and results have no repeats
+// (the first of equal elements stays).
+// Results are new lists (with the options of the receiver): elements of the receiver come first,
+// in its order, then elements of the other list (Union, SymmetricDifference), in its order.
+// Both lists are read locked together in a stable order, so concurrent operations on the same
+// pair of lists don't deadlock. A nil list is an empty one.
+// '...By' variants compare keys of elements (the key must be comparable, like map keys);
+// key functions run as callbacks of both lists.
+
+// SetOptions : options of set operations
+type SetOptions struct {
+	distinct bool
+}
+
+// WithDistinct : set operations ignore repeats, results have no repeats
+func WithDistinct() func(*SetOptions) {
+	return func(o *SetOptions) {
+		o.distinct = true
+	}
+}
+
+// setParams : returns options of set operations
+func setParams(opt []func(*SetOptions)) SetOptions {
+	o := SetOptions{}
+	for _, optSet := range opt {
+		optSet(&o)
+	}
+
+	return o
+}
+
+// Union : returns elements of the receiver and elements of 'other' that are not in the receiver.
+// Multiset: an element gets the larger of its counts in the lists.
+//
+// Example:
+//
+//	a := xlist.New[int](1, 2, 2, 3)
+//	b := xlist.New[int](2, 4, 2, 2)
+//
+//	a.Union(b)                       // 1, 2, 2, 3, 4, 2
+//	a.Union(b, xlist.WithDistinct()) // 1, 2, 3, 4
+//	a.Intersect(b)                   // 2, 2
+//	a.Difference(b)                  // 1, 3
+//	a.SymmetricDifference(b)         // 1, 3, 4, 2
+func (p *XList[T]) Union(other *XList[T], opt ...func(*SetOptions)) *XList[T] {
+	return union(p, other, identity[T], setParams(opt))
+}
+
+// UnionBy : same as Union, comparing keys of elements
+func (p *XList[T]) UnionBy(other *XList[T], key func(T) any, opt ...func(*SetOptions)) *XList[T] {
+	return union(p, other, key, setParams(opt))
+}
+
+// Intersect : returns elements of the receiver that are in 'other'.
+// Multiset: an element gets the smaller of its counts in the lists.
+func (p *XList[T]) Intersect(other *XList[T], opt ...func(*SetOptions)) *XList[T] {
+	return intersect(p, other, identity[T], setParams(opt))
+}
+
+// IntersectBy : same as Intersect, comparing keys of elements
+func (p *XList[T]) IntersectBy(other *XList[T], key func(T) any, opt ...func(*SetOptions)) *XList[T] {
+	return intersect(p, other, key, setParams(opt))
+}
+
+// Difference : returns elements of the receiver that are not in 'other'.
+// Multiset: each occurrence in 'other' removes one occurrence of the element.
+func (p *XList[T]) Difference(other *XList[T], opt ...func(*SetOptions)) *XList[T] {
+	return difference(p, other, identity[T], setParams(opt))
+}
+
+// DifferenceBy : same as Difference, comparing keys of elements
+func (p *XList[T]) DifferenceBy(other *XList[T], key func(T) any, opt ...func(*SetOptions)) *XList[T] {
+	return difference(p, other, key, setParams(opt))
+}
+
+// SymmetricDifference : returns elements of the receiver that are not in 'other', then elements of 'other'
+// that are not in the receiver (multiset: counted like in Difference)
+func (p *XList[T]) SymmetricDifference(other *XList[T], opt ...func(*SetOptions)) *XList[T] {
+	return symmetricDifference(p, other, identity[T], setParams(opt))
+}
+
+// SymmetricDifferenceBy : same as SymmetricDifference, comparing keys of elements
+func (p *XList[T]) SymmetricDifferenceBy(other *XList[T], key func(T) any, opt ...func(*SetOptions)) *XList[T] {
+	return symmetricDifference(p, other, key, setParams(opt))
+}
+
+// IsSubsetOf : checks whether all elements of the receiver are in 'other'.
+// Multiset: 'other' must have each element at least as many times as the receiver.
+func (p *XList[T]) IsSubsetOf(other *XList[T], opt ...func(*SetOptions)) bool {
+	return isSubset(p, other, identity[T], setParams(opt))
+}
+
+// IsSubsetOfBy : same as IsSubsetOf, comparing keys of elements
+func (p *XList[T]) IsSubsetOfBy(other *XList[T], key func(T) any, opt ...func(*SetOptions)) bool {
+	return isSubset(p, other, key, setParams(opt))
+}
+
+// IsSupersetOf : checks whether all elements of 'other' are in the receiver (see IsSubsetOf)
+func (p *XList[T]) IsSupersetOf(other *XList[T], opt ...func(*SetOptions)) bool {
+	return isSubset(orEmpty(other), p, identity[T], setParams(opt))
+}
+
+// IsSupersetOfBy : same as IsSupersetOf, comparing keys of elements
+func (p *XList[T]) IsSupersetOfBy(other *XList[T], key func(T) any, opt ...func(*SetOptions)) bool {
+	return isSubset(orEmpty(other), p, key, setParams(opt))
+}
+
+// Disjoint : checks whether the lists have no common elements
+func (p *XList[T]) Disjoint(other *XList[T]) bool {
+	return disjoint(p, other, identity[T])
+}
+
+// DisjointBy : same as Disjoint, comparing keys of elements
+func (p *XList[T]) DisjointBy(other *XList[T], key func(T) any) bool {
+	return disjoint(p, other, key)
+}
+
+// ----------------------------------------------------------
+
+func union[T, K comparable](a, b *XList[T], key func(T) K, o SetOptions) *XList[T] {
+	b = orEmpty(b)
+	res := newSetResult[T, K](o)
+
+	a.runCallbacksPair(b, func() {
+		inA := countKeys(a, key, o)
+		for xobj := a.home; xobj != nil; xobj = xobj.next {
+			res.add(*xobj.obj, key(*xobj.obj))
+		}
+
+		for xobj := b.home; xobj != nil; xobj = xobj.next {
+			if k := key(*xobj.obj); !inA.take(k) {
+				res.add(*xobj.obj, k)
+			}
+		}
+	})
+
+	return a.adopt(res.list)
+}
+
+func intersect[T, K comparable](a, b *XList[T], key func(T) K, o SetOptions) *XList[T] {
+	b = orEmpty(b)
+	res := newSetResult[T, K](o)
+
+	a.runCallbacksPair(b, func() {
+		inB := countKeys(b, key, o)
+		for xobj := a.home; xobj != nil; xobj = xobj.next {
+			if k := key(*xobj.obj); inB.take(k) {
+				res.add(*xobj.obj, k)
+			}
+		}
+	})
+
+	return a.adopt(res.list)
+}
+
+func difference[T, K comparable](a, b *XList[T], key func(T) K, o SetOptions) *XList[T] {
+	b = orEmpty(b)
+	res := newSetResult[T, K](o)
+
+	a.runCallbacksPair(b, func() {
+		inB := countKeys(b, key, o)
+		for xobj := a.home; xobj != nil; xobj = xobj.next {
+			if k := key(*xobj.obj); !inB.take(k) {
+				res.add(*xobj.obj, k)
+			}
+		}
+	})
+
+	return a.adopt(res.list)
+}
+
+func symmetricDifference[T, K comparable](a, b *XList[T], key func(T) K, o SetOptions) *XList[T] {
+	b = orEmpty(b)
+	res := newSetResult[T, K](o)
+
+	a.runCallbacksPair(b, func() {
+		inA, inB := countKeys(a, key, o), countKeys(b, key, o)
+		for xobj := a.home; xobj != nil; xobj = xobj.next {
+			if k := key(*xobj.obj); !inB.take(k) {
+				res.add(*xobj.obj, k)
+			}
+		}
+
+		for xobj := b.home; xobj != nil; xobj = xobj.next {
+			if k := key(*xobj.obj); !inA.take(k) {
+				res.add(*xobj.obj, k)
+			}
+		}
+	})
+
+	return a.adopt(res.list)
+}
+
+func isSubset[T, K comparable](a, b *XList[T], key func(T) K, o SetOptions) bool {
+	b = orEmpty(b)
+	subset := true
+
+	a.runCallbacksPair(b, func() {
+		inB := countKeys(b, key, o)
+		for xobj := a.home; xobj != nil; xobj = xobj.next {
+			if !inB.take(key(*xobj.obj)) {
+				subset = false
+				return
+			}
+		}
+	})
+
+	return subset
+}
+
+func disjoint[T, K comparable](a, b *XList[T], key func(T) K) bool {
+	b = orEmpty(b)
+	found := false
+
+	a.runCallbacksPair(b, func() {
+		inB := countKeys(b, key, SetOptions{distinct: true})
+		for xobj := a.home; xobj != nil; xobj = xobj.next {
+			if inB.take(key(*xobj.obj)) {
+				found = true
+				return
+			}
+		}
+	})
+
+	return !found
+}
+
+// keyBag : counts of keys of a list; in distinct mode only presence of keys matters
+type keyBag[K comparable] struct {
+	counts   map[K]int
+	distinct bool
+}
+
+// countKeys : returns keys of elements of 'l' (internal: list is locked by caller)
+func countKeys[T, K comparable](l *XList[T], key func(T) K, o SetOptions) keyBag[K] {
+	counts := make(map[K]int, l.length())
+	for xobj := l.home; xobj != nil; xobj = xobj.next {
+		counts[key(*xobj.obj)]++
+	}
+
+	return keyBag[K]{counts: counts, distinct: o.distinct}
+}
+
+// take : reports whether 'k' is in the bag and takes one occurrence of it (multiset mode)
+func (b keyBag[K]) take(k K) bool {
+	if b.counts[k] == 0 {
+		return false
+	}
+
+	if !b.distinct {
+		b.counts[k]--
+	}
+
+	return true
+}
+
+// setResult : result of a set operation; in distinct mode elements with repeated keys are skipped
+type setResult[T, K comparable] struct {
+	list *XList[T]
+	seen map[K]struct{} // nil - multiset mode
+}
+
+func newSetResult[T, K comparable](o SetOptions) *setResult[T, K] {
+	res := &setResult[T, K]{list: newTemp[T]()}
+	if o.distinct {
+		res.seen = make(map[K]struct{})
+	}
+
+	return res
+}
+
+// add : appends 'v' with key 'k' to the result
+func (r *setResult[T, K]) add(v T, k K) {
+	if r.seen != nil {
+		if _, ok := r.seen[k]; ok {
+			return
+		}
+		r.seen[k] = struct{}{}
+	}
+
+	r.list.append(v)
+}
+
+// orEmpty : returns 'l' or a new empty list if it's nil
+func orEmpty[T comparable](l *XList[T]) *XList[T] {
+	if l == nil {
+		return newTemp[T]()
+	}
+
+	return l
+}
+
+// identity : key of an element for set operations without key function
+func identity[T any](v T) T {
+	return v
+}
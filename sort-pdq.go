@@ -43,13 +43,25 @@ func pdqNextPowerOfTwo(length int) uint {
 //   - compare: A function that compares two elements.
 //     Returns true when `a` should be before `b`, otherwise false.
 func (p *XList[T]) PDQSort(compare func(a, b T) bool) {
-	n := p.size
-	if n < 2 {
+	if p.reentrant() {
+		if err := p.deferAction(func() { p.PDQSort(compare) }); err != nil {
+			panic(err)
+		}
 		return
 	}
 
-	p.mtx.Lock()
-	defer p.mtx.Unlock()
+	p.lock()
+	defer p.unlock()
+
+	p.pdqSort(p.home, p.end, p.length(), compare)
+}
+
+// pdqSort : sorts segment [lo..hi] of length n (internal: list is locked by caller)
+func (p *XList[T]) pdqSort(lo, hi *xlistObj[T], n int, compare func(a, b T) bool) {
+	if n < 2 {
+		return
+	}
+	p.valueChanged(nil)
 
 	less := func(a, b *xlistObj[T]) bool {
 		return compare(*a.obj, *b.obj)
@@ -64,7 +76,7 @@ func (p *XList[T]) PDQSort(compare func(a, b T) bool) {
 	var active atomic.Int32
 
 	var wg sync.WaitGroup
-	pdqsortListP(p.home, p.end, n, bits.Len(uint(n)), less, &active, maxWorkers, &wg)
+	pdqsortListP(lo, hi, n, bits.Len(uint(n)), less, &active, maxWorkers, &wg)
 	wg.Wait()
 }
 
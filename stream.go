@@ -0,0 +1,204 @@
+// stream.go
+// Fluent lazy pipeline over `range` iterators
+// Created by Vokhmin D.A. 10.2026
+
+package xlist
+
+import (
+	"iter"
+	"slices"
+)
+
+// Stream : lazy pipeline of steps over a sequence of values.
+// Steps (Filter, Skip, Limit, ...) only describe the pipeline, values are pulled
+// by a terminal (ToSlice, Count, Collect, ...) in one pass.
+// Values may be of any type; steps and terminals that need comparable values
+// (Distinct, Collect) are free functions.
+// Sorted and Reverse need all values of the previous steps, so they buffer them when the terminal runs.
+//
+// Example:
+//
+//	names := list.Stream().
+//		Filter(func(u User) bool { return u.Active }).
+//		Sorted(func(a, b User) bool { return a.Name < b.Name }).
+//		Limit(10).
+//		ToSlice()
+type Stream[T any] struct {
+	seq iter.Seq[T]
+}
+
+// Stream : returns Stream over values of the list. Range options select the elements and iteration mode
+// (the default locked mode keeps the list read locked while the terminal runs).
+func (p *XList[T]) Stream(opt ...func(*RangeOptions)) Stream[T] {
+	return Stream[T]{seq: p.Values(opt...)}
+}
+
+// StreamOf : returns Stream over 'seq'
+func StreamOf[T any](seq iter.Seq[T]) Stream[T] {
+	return Stream[T]{seq: seq}
+}
+
+// MapStream : transforms Stream of T into Stream of V (type-changing step)
+func MapStream[T, V any](s Stream[T], transform func(T) V) Stream[V] {
+	return Stream[V]{seq: Map(s.seq, transform)}
+}
+
+// Seq : returns the pipeline as `range` iterator
+func (s Stream[T]) Seq() iter.Seq[T] {
+	return s.seq
+}
+
+// ----------------------------------------------------------
+// Steps
+
+// Filter : passes through values for which 'is' returns true
+func (s Stream[T]) Filter(is func(T) bool) Stream[T] {
+	return Stream[T]{seq: func(yield func(T) bool) {
+		for v := range s.seq {
+			if is(v) && !yield(v) {
+				return
+			}
+		}
+	}}
+}
+
+// Skip : skips the first 'n' values
+func (s Stream[T]) Skip(n int) Stream[T] {
+	return Stream[T]{seq: func(yield func(T) bool) {
+		i := 0
+		for v := range s.seq {
+			if i < n {
+				i++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}}
+}
+
+// Limit : passes through not more than 'n' values, the previous steps are not pulled after that
+func (s Stream[T]) Limit(n int) Stream[T] {
+	return Stream[T]{seq: func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+
+		i := 0
+		for v := range s.seq {
+			if !yield(v) {
+				return
+			}
+			if i++; i >= n {
+				return
+			}
+		}
+	}}
+}
+
+// Peek : calls 'fn' for each value passing through (logging, debugging)
+func (s Stream[T]) Peek(fn func(T)) Stream[T] {
+	return Stream[T]{seq: func(yield func(T) bool) {
+		for v := range s.seq {
+			fn(v)
+			if !yield(v) {
+				return
+			}
+		}
+	}}
+}
+
+// Distinct : passes through the first occurrence of each value of the stream
+func Distinct[T comparable](s Stream[T]) Stream[T] {
+	return Stream[T]{seq: func(yield func(T) bool) {
+		seen := make(map[T]struct{})
+		for v := range s.seq {
+			if _, ok := seen[v]; ok {
+				continue
+			}
+			seen[v] = struct{}{}
+
+			if !yield(v) {
+				return
+			}
+		}
+	}}
+}
+
+// Sorted : sorts values with 'compare' (returns true when 'a' should be before 'b'); the sort is stable.
+// Buffers all values of the previous steps.
+func (s Stream[T]) Sorted(compare func(a, b T) bool) Stream[T] {
+	return Stream[T]{seq: func(yield func(T) bool) {
+		values := slices.Collect(s.seq)
+		slices.SortStableFunc(values, func(a, b T) int {
+			switch {
+			case compare(a, b):
+				return -1
+			case compare(b, a):
+				return 1
+			default:
+				return 0
+			}
+		})
+
+		for _, v := range values {
+			if !yield(v) {
+				return
+			}
+		}
+	}}
+}
+
+// Reverse : reverses order of values. Buffers all values of the previous steps.
+func (s Stream[T]) Reverse() Stream[T] {
+	return Stream[T]{seq: func(yield func(T) bool) {
+		values := slices.Collect(s.seq)
+		for i := len(values) - 1; i >= 0; i-- {
+			if !yield(values[i]) {
+				return
+			}
+		}
+	}}
+}
+
+// ----------------------------------------------------------
+// Terminals
+
+// ToSlice : returns slice of the values
+func (s Stream[T]) ToSlice() []T {
+	return slices.Collect(s.seq)
+}
+
+// Count : returns number of the values
+func (s Stream[T]) Count() int {
+	return Count(s.seq)
+}
+
+// First : returns the first value, 'false' if there are no values
+func (s Stream[T]) First() (T, bool) {
+	return First(s.seq)
+}
+
+// ForEach : calls 'fn' for each value
+func (s Stream[T]) ForEach(fn func(T)) {
+	for v := range s.seq {
+		fn(v)
+	}
+}
+
+// Reduce : combines the values with 'fn' from the first one to the last one
+// (the first value is the initial accumulator). Returns 'false' if there are no values.
+func (s Stream[T]) Reduce(fn func(acc, v T) T) (T, bool) {
+	return Reduce(s.seq, fn)
+}
+
+// Collect : returns new list of the values of the stream
+func Collect[T comparable](s Stream[T]) *XList[T] {
+	list := New[T]()
+	for v := range s.seq {
+		list.append(v)
+	}
+
+	return list
+}
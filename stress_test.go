@@ -0,0 +1,237 @@
+package xlist
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Stress suite: every public operation runs concurrently with all the others.
+// Run with 'go test -race' to check the locking of the whole API.
+
+const (
+	stressWorkers    = 8
+	stressIterations = 2000
+)
+
+// stressOps : one entry per public operation of XList and Iterator
+var stressOps = []func(list, other *XList[int], gen *rand.Rand){
+	func(list, _ *XList[int], gen *rand.Rand) { list.Append(gen.Intn(100)) },
+	func(list, _ *XList[int], gen *rand.Rand) { list.AppendUnique(gen.Intn(100)) },
+	func(list, _ *XList[int], gen *rand.Rand) { _ = list.Insert(gen.Intn(list.Size()+1), gen.Intn(100), 1) },
+	func(list, _ *XList[int], gen *rand.Rand) { _ = list.Replace(gen.Intn(list.Size()+1), gen.Intn(100)) },
+	func(list, _ *XList[int], gen *rand.Rand) { _ = list.ReplaceLast(gen.Intn(100)) },
+	func(list, _ *XList[int], gen *rand.Rand) { _, _ = list.DeleteAt(gen.Intn(list.Size() + 1)) },
+	func(list, _ *XList[int], _ *rand.Rand) { _, _ = list.DeleteLast() },
+	func(list, _ *XList[int], gen *rand.Rand) {
+		_ = list.Swap(gen.Intn(list.Size()+1), gen.Intn(list.Size()+1))
+	},
+	func(list, _ *XList[int], gen *rand.Rand) { _, _ = list.At(gen.Intn(list.Size() + 1)) },
+	func(list, _ *XList[int], gen *rand.Rand) { _ = list.AtPtr(gen.Intn(list.Size() + 1)) },
+	func(list, _ *XList[int], _ *rand.Rand) { _, _ = list.LastObject() },
+	func(list, _ *XList[int], _ *rand.Rand) { _ = list.IsEmpty() },
+	func(list, _ *XList[int], _ *rand.Rand) { _ = list.Size() },
+	func(list, _ *XList[int], gen *rand.Rand) { _ = list.Contains(gen.Intn(100)) },
+	func(list, _ *XList[int], gen *rand.Rand) { _ = list.ContainsSome(gen.Intn(100), gen.Intn(100)) },
+	func(list, _ *XList[int], _ *rand.Rand) { _ = list.Slice() },
+	func(list, _ *XList[int], _ *rand.Rand) { _ = list.Copy() },
+	func(list, _ *XList[int], gen *rand.Rand) { _, _ = list.CopyRange(0, gen.Intn(list.Size()+1)) },
+	func(list, _ *XList[int], _ *rand.Rand) { _ = list.DeepCopy(func(v int) int { return v }) },
+	func(list, _ *XList[int], gen *rand.Rand) {
+		_ = list.Find(func(_ int, v int) bool { return v%2 == gen.Intn(2) })
+	},
+	func(list, _ *XList[int], _ *rand.Rand) {
+		list.Modify(func(_ int, v int) int { return (v + 1) % 100 })
+	},
+	func(list, _ *XList[int], _ *rand.Rand) {
+		list.ModifyRev(func(_ int, v int) int { return (v + 99) % 100 })
+	},
+	func(list, _ *XList[int], gen *rand.Rand) { list.MarkAtIndex(gen.Intn(list.Size() + 1)) },
+	func(list, _ *XList[int], gen *rand.Rand) { list.UnmarkAtIndex(gen.Intn(list.Size() + 1)) },
+	func(list, _ *XList[int], gen *rand.Rand) { _ = list.IsMarkedAtIndex(gen.Intn(list.Size() + 1)) },
+	func(list, _ *XList[int], _ *rand.Rand) { list.MarkAll() },
+	func(list, _ *XList[int], _ *rand.Rand) { list.UnmarkAll() },
+	func(list, _ *XList[int], _ *rand.Rand) { list.Sort(func(a, b int) bool { return a < b }) },
+	func(list, _ *XList[int], _ *rand.Rand) {
+		for range list.All() {
+		}
+	},
+	func(list, _ *XList[int], _ *rand.Rand) {
+		for range list.ValuesBackward() {
+		}
+	},
+	func(list, _ *XList[int], _ *rand.Rand) {
+		for range list.All(WithSnapshot()) {
+		}
+	},
+	func(list, _ *XList[int], gen *rand.Rand) {
+		for i := range list.Backward(WithLive()) {
+			if gen.Intn(10) == 0 {
+				_, _ = list.DeleteAt(i)
+			}
+		}
+	},
+	func(list, other *XList[int], gen *rand.Rand) {
+		other.Append(gen.Intn(100))
+		list.AppendList(other)
+	},
+	func(list, other *XList[int], gen *rand.Rand) {
+		other.Append(gen.Intn(100))
+		_ = list.SpliceAtPos(gen.Intn(list.Size()+1), other)
+	},
+	func(list, other *XList[int], gen *rand.Rand) {
+		// opposite lock order of the two lists
+		list.Append(gen.Intn(100))
+		other.Splice(list)
+		list.Splice(other)
+	},
+	func(list, _ *XList[int], _ *rand.Rand) {
+		it := list.Iterator()
+		for it.Next() {
+			_, _ = it.Value()
+			_ = it.Index()
+		}
+		for it.Prev() {
+		}
+	},
+	func(list, _ *XList[int], gen *rand.Rand) {
+		it := list.Iterator(0, gen.Intn(list.Size()+1))
+		_, _ = it.SetFirst()
+		_, _ = it.NextValue()
+		_, _ = it.SetLast()
+		_, _ = it.PrevValue()
+		_, _ = it.SetIndex(gen.Intn(list.Size() + 1))
+		it.Reset()
+	},
+	func(list, _ *XList[int], gen *rand.Rand) {
+		it := list.Iterator()
+		for it.Next() {
+			switch gen.Intn(4) {
+			case 0:
+				_ = it.Remove()
+			case 1:
+				_ = it.Set(gen.Intn(100))
+			case 2:
+				_ = it.InsertAfter(gen.Intn(100))
+			default:
+				_ = it.Mark()
+			}
+		}
+	},
+	func(list, _ *XList[int], gen *rand.Rand) {
+		for _, ref := range list.AllMut() {
+			if gen.Intn(5) == 0 {
+				_ = ref.Delete()
+			} else {
+				_ = ref.Set(ref.Get() + 1)
+			}
+		}
+	},
+	func(list, _ *XList[int], gen *rand.Rand) {
+		cursor := ""
+		for range 3 {
+			page, err := list.Page(cursor, 1+gen.Intn(10))
+			if err != nil || page.Next == "" {
+				return
+			}
+			cursor = page.Next
+		}
+	},
+	func(list, _ *XList[int], _ *rand.Rand) {
+		_ = list.ParallelFind(func(_ int, v int) bool { return v%2 == 0 }, WithWorkers(3), WithMinSegment(1))
+	},
+	func(list, _ *XList[int], _ *rand.Rand) {
+		list.ParallelModify(func(_ int, v int) int { return (v + 1) % 100 }, WithWorkers(3), WithMinSegment(1))
+	},
+	func(list, _ *XList[int], gen *rand.Rand) {
+		if gen.Intn(50) == 0 {
+			list.Set(1, 2, 3)
+		}
+	},
+	func(list, _ *XList[int], gen *rand.Rand) {
+		if gen.Intn(100) == 0 {
+			list.Clear()
+		}
+	},
+}
+
+func TestStressConcurrentAPI(t *testing.T) {
+	list := New[int](1, 2, 3, 4, 5)
+	other := New[int]()
+
+	wg := sync.WaitGroup{}
+	wg.Add(stressWorkers)
+
+	for w := range stressWorkers {
+		go func(seed int64) {
+			defer wg.Done()
+
+			gen := rand.New(rand.NewSource(seed))
+			for range stressIterations {
+				stressOps[gen.Intn(len(stressOps))](list, other, gen)
+
+				// keep the list short, so the operations stay cheap
+				if list.Size() > 200 {
+					list.Clear()
+				}
+			}
+		}(int64(w))
+	}
+
+	wg.Wait()
+
+	checkChain(t, list)
+	checkChain(t, other)
+}
+
+// shared iterator and shared list: iterator state is protected by its own mutex
+func TestStressSharedIterator(t *testing.T) {
+	list := New[int]()
+	for i := range 100 {
+		list.Append(i)
+	}
+
+	it := list.Iterator()
+	wg := sync.WaitGroup{}
+	wg.Add(stressWorkers)
+
+	for range stressWorkers {
+		go func() {
+			defer wg.Done()
+
+			for range stressIterations {
+				if !it.Next() {
+					it.Reset()
+				}
+				_, _ = it.Value()
+				list.Append(1)
+				_, _ = list.DeleteAt(0)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 100, list.Size())
+	checkChain(t, list)
+}
+
+// checkChain : verifies links and size of the list
+func checkChain[T comparable](t *testing.T, list *XList[T]) {
+	t.Helper()
+
+	defer list.runlock(list.rlock())
+
+	n := 0
+	var prev *xlistObj[T]
+	for xobj := list.home; xobj != nil; xobj = xobj.next {
+		assert.Equal(t, prev, xobj.prev)
+		prev = xobj
+		n++
+	}
+
+	assert.Equal(t, prev, list.end)
+	assert.Equal(t, list.length(), n)
+}
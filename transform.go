@@ -0,0 +1,140 @@
+// transform.go
+// Type-changing transforms of the list: MapList, FilterMap, GroupBy, ToMap, Partition
+// Created by Vokhmin D.A. 10.2026
+
+package xlist
+
+// The source list is read under one read lock; callbacks can read it, mutations of the source
+// inside callbacks fail with ErrReentrantCall (see WithDeferredReentrancy).
+// Result lists get the options of the source list (see NewWithOptions).
+
+// MapList : returns new list of 'fn' results for each element of 'l'.
+//
+// Example:
+//
+//	names := xlist.MapList(users, func(u User) string { return u.Name })
+func MapList[T, V comparable](l *XList[T], fn func(T) V) *XList[V] {
+	result := newTemp[V]()
+
+	l.runCallbacks(false, func() {
+		for v := range l.chain() {
+			result.append(fn(v))
+		}
+	})
+
+	return adoptAs(l, result)
+}
+
+// MapListErr : same as MapList, but stops on the first error of 'fn' and returns it (the result is nil then)
+func MapListErr[T, V comparable](l *XList[T], fn func(T) (V, error)) (*XList[V], error) {
+	result := newTemp[V]()
+	var err error
+
+	l.runCallbacks(false, func() {
+		for v := range l.chain() {
+			var r V
+			if r, err = fn(v); err != nil {
+				return
+			}
+			result.append(r)
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return adoptAs(l, result), nil
+}
+
+// FilterMap : returns new list of 'fn' results for elements of 'l' for which 'fn' returns true
+//
+// Example:
+//
+//	ids := xlist.FilterMap(lines, func(s string) (int, bool) {
+//		id, err := strconv.Atoi(s)
+//		return id, err == nil
+//	})
+func FilterMap[T, V comparable](l *XList[T], fn func(T) (V, bool)) *XList[V] {
+	result := newTemp[V]()
+
+	l.runCallbacks(false, func() {
+		for v := range l.chain() {
+			if r, ok := fn(v); ok {
+				result.append(r)
+			}
+		}
+	})
+
+	return adoptAs(l, result)
+}
+
+// GroupBy : splits elements of 'l' into lists by 'key'; elements keep their order in each group
+//
+// Example:
+//
+//	byDept := xlist.GroupBy(staff, func(e Employee) string { return e.Dept })
+//	fmt.Println(byDept["sales"].Size())
+func GroupBy[T, K comparable](l *XList[T], key func(T) K) map[K]*XList[T] {
+	groups := make(map[K]*XList[T])
+
+	l.runCallbacks(false, func() {
+		for v := range l.chain() {
+			k := key(v)
+
+			group, ok := groups[k]
+			if !ok {
+				group = newTemp[T]()
+				groups[k] = group
+			}
+			group.append(v)
+		}
+	})
+
+	for _, group := range groups {
+		l.adopt(group)
+	}
+
+	return groups
+}
+
+// ToMap : returns map of key-value pairs returned by 'fn' for each element of 'l';
+// for equal keys the last element wins
+func ToMap[T, K comparable, V any](l *XList[T], fn func(T) (K, V)) map[K]V {
+	result := make(map[K]V)
+
+	l.runCallbacks(false, func() {
+		for v := range l.chain() {
+			k, r := fn(v)
+			result[k] = r
+		}
+	})
+
+	return result
+}
+
+// IndexBy : returns map of elements of 'l' by 'key'; for equal keys the last element wins
+//
+// Example:
+//
+//	byID := xlist.IndexBy(users, func(u *User) int64 { return u.ID })
+func IndexBy[T, K comparable](l *XList[T], key func(T) K) map[K]T {
+	return ToMap(l, func(v T) (K, T) { return key(v), v })
+}
+
+// Partition : splits elements of 'l' into two new lists: for which 'is' returns true and false
+func Partition[T comparable](l *XList[T], is func(T) bool) (yes, no *XList[T]) {
+	yes, no = newTemp[T](), newTemp[T]()
+
+	l.runCallbacks(false, func() {
+		for v := range l.chain() {
+			if is(v) {
+				yes.append(v)
+			} else {
+				no.append(v)
+			}
+		}
+	})
+
+	return l.adopt(yes), l.adopt(no)
+}
@@ -0,0 +1,178 @@
+// unique.go
+// Deduplication: AppendUnique variants, Unique and UniqueBy
+// Created by Vokhmin D.A. 10.2026
+
+package xlist
+
+// Hasher : optional interface of element types for deduplication (AppendUnique, Unique).
+// Elements with equal hashes are compared with Equal; without it elements are compared with ==.
+// It's useful for pointer types, when pointers to equal values are duplicates.
+// The methods are called for each element of the list, including nil pointers.
+//
+// Example:
+//
+//	func (u *User) Hash() uint64          { return uint64(u.ID) }
+//	func (u *User) Equal(other *User) bool { return u.ID == other.ID }
+type Hasher[T any] interface {
+	Hash() uint64
+	Equal(other T) bool
+}
+
+// AppendUniqueBy : appends objects whose keys don't exist in the list (see AppendUnique);
+// the key must be comparable, like map keys.
+// Returns self for method chaining; return value can be ignored.
+//
+// Example:
+//
+//	users.AppendUniqueBy(func(u *User) any { return u.ID }, newUsers...)
+func (p *XList[T]) AppendUniqueBy(key func(T) any, objects ...T) *XList[T] {
+	if p.reentrant() {
+		if err := p.deferAction(func() { p.AppendUniqueBy(key, objects...) }); err != nil {
+			panic(err)
+		}
+		return p
+	}
+
+	p.appendUnique(seenByKey(key), objects)
+
+	return p
+}
+
+// AppendUniqueFunc : appends objects that aren't equal by 'eq' to any element of the list (see AppendUnique).
+// Each object is compared with all elements, use AppendUniqueBy for long lists.
+// Returns self for method chaining; return value can be ignored.
+func (p *XList[T]) AppendUniqueFunc(eq func(a, b T) bool, objects ...T) *XList[T] {
+	if p.reentrant() {
+		if err := p.deferAction(func() { p.AppendUniqueFunc(eq, objects...) }); err != nil {
+			panic(err)
+		}
+		return p
+	}
+
+	p.appendUnique(seenByFunc(eq), objects)
+
+	return p
+}
+
+// Unique : deletes repeated elements in a single pass, the first one of equal elements stays;
+// returns number of deleted elements. Elements are compared like in AppendUnique.
+func (p *XList[T]) Unique() int {
+	if p.reentrant() {
+		if err := p.deferAction(func() { p.Unique() }); err != nil {
+			panic(err)
+		}
+		return 0
+	}
+
+	return p.unique(newSeenSet[T]())
+}
+
+// UniqueBy : deletes elements with repeated keys in a single pass, the first one of them stays;
+// returns number of deleted elements. The key must be comparable, like map keys.
+func (p *XList[T]) UniqueBy(key func(T) any) int {
+	if p.reentrant() {
+		if err := p.deferAction(func() { p.UniqueBy(key) }); err != nil {
+			panic(err)
+		}
+		return 0
+	}
+
+	return p.unique(seenByKey(key))
+}
+
+// appendUnique : appends objects not seen in the list or before them in 'objects'
+func (p *XList[T]) appendUnique(seen seenSet[T], objects []T) {
+	p.runCallbacks(true, func() {
+		for xobj := p.home; xobj != nil; xobj = xobj.next {
+			seen(*xobj.obj)
+		}
+
+		for _, obj := range objects {
+			if !seen(obj) {
+				p.append(obj)
+			}
+		}
+	})
+}
+
+// unique : deletes elements seen before them, returns their number
+func (p *XList[T]) unique(seen seenSet[T]) int {
+	n := 0
+
+	p.runCallbacks(true, func() {
+		for xobj := p.home; xobj != nil; {
+			next := xobj.next
+			if seen(*xobj.obj) {
+				p.unlink(xobj)
+				n++
+			}
+			xobj = next
+		}
+	})
+
+	return n
+}
+
+// ----------------------------------------------------------
+
+// seenSet : records 'obj' and reports whether an equal one was recorded before
+type seenSet[T any] func(obj T) bool
+
+// newSeenSet : set comparing by Hasher[T] if T implements it, by == otherwise
+func newSeenSet[T comparable]() seenSet[T] {
+	var zero T
+	if _, ok := any(zero).(Hasher[T]); ok {
+		buckets := make(map[uint64][]T)
+
+		return func(obj T) bool {
+			h := any(obj).(Hasher[T])
+			hash := h.Hash()
+			for _, other := range buckets[hash] {
+				if h.Equal(other) {
+					return true
+				}
+			}
+			buckets[hash] = append(buckets[hash], obj)
+			return false
+		}
+	}
+
+	set := make(map[T]struct{})
+
+	return func(obj T) bool {
+		if _, ok := set[obj]; ok {
+			return true
+		}
+		set[obj] = struct{}{}
+		return false
+	}
+}
+
+// seenByKey : set comparing by keys
+func seenByKey[T any](key func(T) any) seenSet[T] {
+	set := make(map[any]struct{})
+
+	return func(obj T) bool {
+		k := key(obj)
+		if _, ok := set[k]; ok {
+			return true
+		}
+		set[k] = struct{}{}
+		return false
+	}
+}
+
+// seenByFunc : set comparing by 'eq' with each recorded object
+func seenByFunc[T any](eq func(a, b T) bool) seenSet[T] {
+	var recorded []T
+
+	return func(obj T) bool {
+		for _, other := range recorded {
+			if eq(other, obj) {
+				return true
+			}
+		}
+		recorded = append(recorded, obj)
+		return false
+	}
+}
@@ -0,0 +1,360 @@
+// view.go
+// Live sublist views over a range of the list
+// Created by Vokhmin D.A. 10.2026
+
+package xlist
+
+import "iter"
+
+// View : live window over a range of elements of the list (see XList.View).
+// Operations act directly on the elements of the parent list under its lock; indexes are
+// relative to the view. The view is bounded by the neighbours of its range, so elements
+// inserted between them (including at the edges of the view) join it, and deleted elements leave it.
+// Clear, Set or splicing the parent into another list close the view: further operations
+// return ErrViewClosed (At and Size return nothing).
+// A view subscribes to changes of the parent: Close it when it is not needed anymore.
+type View[T comparable] struct {
+	list *XList[T]
+
+	before *xlistObj[T] // neighbour before the first element of the view, nil - the view starts at home
+	after  *xlistObj[T] // neighbour after the last element of the view, nil - the view ends at end
+	closed bool
+}
+
+// View : returns live view of elements [from, to] of the list (indexes are inclusive, like in CopyRange);
+// 'to' = -1 means the last element. The whole range (0, -1) of an empty list gives a view of the whole list.
+//
+// Example:
+//
+//	v, err := list.View(1000, 1999)
+//	if err != nil {
+//		return err
+//	}
+//	defer v.Close()
+//
+//	v.Sort(func(a, b int) bool { return a < b }) // sorts the elements in place, without copying
+func (p *XList[T]) View(from, to int) (*View[T], error) {
+	if p.reentrant() {
+		panic(ErrReentrantCall)
+	}
+
+	p.lock()
+	defer p.unlock()
+
+	view := &View[T]{list: p}
+
+	size := p.length()
+	if to == -1 {
+		to = size - 1
+	}
+
+	if from != 0 || to != size-1 {
+		if from < 0 || from > size-1 || to < 0 || to > size-1 || from > to {
+			return nil, ErrInvalidIndex
+		}
+
+		first := p.objectAt(from, nil, 0)
+		last := p.objectAt(to, first, from)
+		view.before, view.after = first.prev, last.next
+	}
+
+	p.observe(view)
+
+	return view, nil
+}
+
+// Close : unsubscribes the view from changes of the parent list; the view is closed after that
+func (v *View[T]) Close() {
+	p := v.list
+	if p.reentrant() {
+		if err := p.deferAction(v.Close); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	p.lock()
+	defer p.unlock()
+
+	// a view closed by Clear is still subscribed
+	v.closed = true
+	p.unobserve(v)
+}
+
+// Size : returns number of elements in the view (0 if the view is closed)
+func (v *View[T]) Size() int {
+	p := v.list
+	defer p.runlock(p.rlock())
+
+	n := 0
+	for range v.objects() {
+		n++
+	}
+
+	return n
+}
+
+// At : returns element at index 'pos' of the view
+func (v *View[T]) At(pos int) (T, bool) {
+	p := v.list
+	defer p.runlock(p.rlock())
+
+	if xobj := v.objectAt(pos); xobj != nil {
+		return *xobj.obj, true
+	}
+
+	var zero T
+	return zero, false
+}
+
+// Replace : replaces element at index 'pos' of the view with 'obj'
+func (v *View[T]) Replace(pos int, obj T) error {
+	p := v.list
+	if p.reentrant() {
+		return p.deferCall(func() error { return v.Replace(pos, obj) })
+	}
+
+	p.lock()
+	defer p.unlock()
+
+	if v.closed {
+		return ErrViewClosed
+	}
+
+	xobj := v.objectAt(pos)
+	if xobj == nil {
+		return ErrElementNotFound
+	}
+	xobj.obj = &obj
+	p.valueChanged(xobj)
+
+	return nil
+}
+
+// Insert : inserts objects before index 'pos' of the view ('pos' = Size - to the end of the view)
+func (v *View[T]) Insert(pos int, objects ...T) error {
+	p := v.list
+	if p.reentrant() {
+		return p.deferCall(func() error { return v.Insert(pos, objects...) })
+	}
+
+	p.lock()
+	defer p.unlock()
+
+	if v.closed {
+		return ErrViewClosed
+	}
+
+	// position 'pos' or 'after' for the end of the view
+	at, i := v.after, 0
+	for xobj := range v.objects() {
+		if i == pos {
+			at = xobj
+			break
+		}
+		i++
+	}
+	if pos < 0 || (at == v.after && i != pos) {
+		return ErrInvalidIndex
+	}
+
+	for _, obj := range objects {
+		p.linkBefore(at, &xlistObj[T]{obj: &obj})
+	}
+
+	return nil
+}
+
+// Append : appends objects to the end of the view
+func (v *View[T]) Append(objects ...T) error {
+	p := v.list
+	if p.reentrant() {
+		return p.deferCall(func() error { return v.Append(objects...) })
+	}
+
+	p.lock()
+	defer p.unlock()
+
+	if v.closed {
+		return ErrViewClosed
+	}
+
+	for _, obj := range objects {
+		p.linkBefore(v.after, &xlistObj[T]{obj: &obj})
+	}
+
+	return nil
+}
+
+// DeleteAt : deletes and returns element at index 'pos' of the view
+func (v *View[T]) DeleteAt(pos int) (T, error) {
+	var zero T
+
+	p := v.list
+	if p.reentrant() {
+		return zero, p.deferCall(func() error { _, err := v.DeleteAt(pos); return err })
+	}
+
+	p.lock()
+	defer p.unlock()
+
+	if v.closed {
+		return zero, ErrViewClosed
+	}
+
+	xobj := v.objectAt(pos)
+	if xobj == nil {
+		return zero, ErrInvalidIndex
+	}
+	p.unlink(xobj)
+
+	return *xobj.obj, nil
+}
+
+// All : returns `range` iterator over (index in the view, value); the parent list is read locked
+// during the loop (like in the locked mode of XList.All)
+func (v *View[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		p := v.list
+		p.runLoop(yield, func(yield func(int, T) bool) {
+			i := 0
+			for xobj := range v.objects() {
+				if !yield(i, *xobj.obj) {
+					return
+				}
+				i++
+			}
+		})
+	}
+}
+
+// Values : returns `range` iterator over values of the view (see All)
+func (v *View[T]) Values() iter.Seq[T] {
+	return ToValues(v.All())
+}
+
+// Slice : returns copy of values of the view
+func (v *View[T]) Slice() []T {
+	p := v.list
+	defer p.runlock(p.rlock())
+
+	var values []T
+	for xobj := range v.objects() {
+		values = append(values, *xobj.obj)
+	}
+
+	return values
+}
+
+// Modify : modifies each element of the view with 'change' (see XList.Modify); 'index' is the index in the view
+func (v *View[T]) Modify(change func(index int, object T) T) error {
+	p := v.list
+	if p.reentrant() {
+		return p.deferCall(func() error { return v.Modify(change) })
+	}
+
+	var err error
+
+	p.runCallbacks(true, func() {
+		if v.closed {
+			err = ErrViewClosed
+			return
+		}
+		p.valueChanged(nil)
+
+		i := 0
+		for xobj := range v.objects() {
+			*xobj.obj = change(i, *xobj.obj)
+			i++
+		}
+	})
+
+	return err
+}
+
+// Sort : sorts elements of the view with PDQSort (see XList.PDQSort); other elements of the list stay in place
+func (v *View[T]) Sort(compare func(a, b T) bool) error {
+	p := v.list
+	if p.reentrant() {
+		return p.deferCall(func() error { return v.Sort(compare) })
+	}
+
+	p.lock()
+	defer p.unlock()
+
+	if v.closed {
+		return ErrViewClosed
+	}
+
+	var first, last *xlistObj[T]
+	n := 0
+	for xobj := range v.objects() {
+		if first == nil {
+			first = xobj
+		}
+		last = xobj
+		n++
+	}
+
+	p.pdqSort(first, last, n, compare)
+
+	return nil
+}
+
+// objects : `range` iterator over objects of the view (internal: list is locked by caller)
+func (v *View[T]) objects() iter.Seq[*xlistObj[T]] {
+	return func(yield func(*xlistObj[T]) bool) {
+		if v.closed {
+			return
+		}
+
+		xobj := v.list.home
+		if v.before != nil {
+			xobj = v.before.next
+		}
+
+		for ; xobj != nil && xobj != v.after; xobj = xobj.next {
+			if !yield(xobj) {
+				return
+			}
+		}
+	}
+}
+
+// objectAt : returns object at index 'pos' of the view or nil (internal: list is locked by caller)
+func (v *View[T]) objectAt(pos int) *xlistObj[T] {
+	if pos < 0 {
+		return nil
+	}
+
+	i := 0
+	for xobj := range v.objects() {
+		if i == pos {
+			return xobj
+		}
+		i++
+	}
+
+	return nil
+}
+
+// observer of the parent list: the neighbours of the view move outwards when they are deleted
+
+func (v *View[T]) linked(*xlistObj[T]) {}
+
+func (v *View[T]) changed(*xlistObj[T]) {}
+
+func (v *View[T]) unlinked(xobj *xlistObj[T]) {
+	// a deleted object keeps links to its former neighbours
+	switch xobj {
+	case v.before:
+		v.before = xobj.prev
+	case v.after:
+		v.after = xobj.next
+	}
+}
+
+func (v *View[T]) cleared() {
+	v.closed = true
+	v.before, v.after = nil, nil
+}
@@ -16,8 +16,24 @@ const sortIndexingFromSize = 2
 var (
 	ErrElementNotFound = errors.New("element not found")
 	ErrInvalidIndex    = errors.New("invalid index")
+	ErrInvalidRange    = errors.New("invalid range")
 	ErrIsNotAPointer   = errors.New("object is not a pointer")
 	ErrNoClosure       = errors.New("no function closure")
+	ErrReentrantCall   = errors.New("reentrant call from a callback of the same list")
+	ErrDeferred        = errors.New("call deferred until the outer operation releases the list")
+
+	ErrConcurrentModification = errors.New("list was modified during iteration")
+	ErrStaleReference         = errors.New("element reference used outside of its loop step")
+	ErrPulledLoop             = errors.New("loop over element references can't be pulled with iter.Pull")
+
+	ErrInvalidCursor  = errors.New("invalid cursor")
+	ErrCursorNotFound = errors.New("cursor element not found")
+
+	ErrViewClosed = errors.New("view is closed")
+
+	ErrIndexExists   = errors.New("index already exists")
+	ErrIndexNotFound = errors.New("index not found")
+	ErrDuplicateKey  = errors.New("duplicate key in unique index")
 )
 
 type Compare[T any] interface {
@@ -28,9 +44,34 @@ type XList[T comparable] struct {
 	home *xlistObj[T] // first object
 	end  *xlistObj[T] // last object
 
-	size int // counts elements inside container
+	size atomic.Int64 // counts elements inside container (read without lock by Size)
+
+	modCount uint64 // counts structural modifications: insert, delete, clear, splice (under mtx)
+	valCount uint64 // counts value modifications: replace, swap, modify, sort (under mtx)
+
+	mtx  sync.RWMutex
+	opts ListOptions // construction options
+
+	// goroutine that owns an unsynchronized list (checked in debug builds only)
+	owner atomic.Int64
+
+	// lock order of the list when two lists are locked together (see lockPair)
+	seq atomic.Uint64
+
+	// goroutines running user callbacks of the list (see reentrancy.go)
+	cbActive atomic.Int32
+	cbMtx    sync.Mutex
+	cbStates []*callbackState
 
-	mtx sync.RWMutex
+	// subscribers of changes (see observers.go)
+	observers []observer[T]
+
+	// secondary key indexes by name (see keyindex.go)
+	indexes map[string]observer[T]
+
+	// registry of cursor objects, created by the first Page call (see pagination.go)
+	pages     *pageRegistry[T]
+	pagesOnce sync.Once
 
 	// Work params ----
 
@@ -38,12 +79,54 @@ type XList[T comparable] struct {
 	sortContext *sortContext[T]
 }
 
+// ListOptions : construction options of XList (see NewWithOptions)
+type ListOptions struct {
+	unsync         bool // skip locking, the list is used by a single goroutine
+	deferReentrant bool // queue mutations called from callbacks instead of failing
+
+	onDeferredError func(err error) // receives errors of deferred mutations
+}
+
+// WithUnsync : creates a list that never locks its mutex.
+// Use it for lists that never leave one goroutine (hot paths, temporary lists).
+// Build with '-tags xlist_debug' to panic when another goroutine touches such a list.
+func WithUnsync() func(*ListOptions) {
+	return func(lo *ListOptions) {
+		lo.unsync = true
+	}
+}
+
+// WithDeferredReentrancy : mutations called from inside a callback of the same list
+// (Find, Modify, ModifyRev, DeepCopyRange, body of All/Backward loop) are queued
+// and applied in order once the outer operation releases the lock.
+// Without this option such calls panic or return ErrReentrantCall.
+// Queued mutations that return an error return ErrDeferred instead of the result,
+// errors they return when applied go to the handler of WithDeferredErrorHandler.
+func WithDeferredReentrancy() func(*ListOptions) {
+	return func(lo *ListOptions) {
+		lo.deferReentrant = true
+	}
+}
+
+// WithDeferredErrorHandler : 'fn' receives errors of mutations deferred by WithDeferredReentrancy,
+// it's called after the lock of the outer operation is released.
+// Without the handler the outer operation panics with the errors once all deferred mutations are applied.
+func WithDeferredErrorHandler(fn func(err error)) func(*ListOptions) {
+	return func(lo *ListOptions) {
+		lo.onDeferredError = fn
+	}
+}
+
 // element of bidirectional XList
 type xlistObj[T comparable] struct {
 	next *xlistObj[T] // pointer to next element in chain
 	prev *xlistObj[T] // pointer to previous element element in chain
 	mark bool         // mark element
 
+	// element was deleted from the list: 'next' and 'prev' still point to its former neighbours,
+	// so a cursor that stays on it can go on (see live iteration in range.go)
+	removed bool
+
 	obj *T
 }
 
@@ -62,8 +145,12 @@ type indexPair[T comparable] struct {
 	obj *xlistObj[T]
 }
 
-// Iterator : optimal for sequential element passes
+// Iterator : optimal for sequential element passes.
+// Each method locks the parent list for reading, so iterator steps are safe to run
+// concurrently with modifications of the list.
 type Iterator[T comparable] struct {
+	mtx sync.Mutex // protects iterator state
+
 	parent *XList[T]    // parent structure
 	index  int          // index
 	lobj   *xlistObj[T] // pointer to XList object
@@ -71,6 +158,12 @@ type Iterator[T comparable] struct {
 	// Allowed range
 	start  int
 	finish int
+
+	// Fail-fast state: modification counters of the parent seen at the last positioning
+	modCount    uint64
+	valCount    uint64
+	allowValues bool  // value modifications of the parent are tolerated
+	err         error // ErrConcurrentModification, iterator is invalid until Reset
 }
 
 // New : create new empty XList container
@@ -87,3 +180,24 @@ func New[T comparable](objects ...T) *XList[T] {
 
 	return &newList
 }
+
+// NewWithOptions : create new empty XList container with construction options.
+//
+// Example:
+//
+//	list := xlist.NewWithOptions[int](xlist.WithUnsync())
+func NewWithOptions[T comparable](opt ...func(*ListOptions)) *XList[T] {
+	newList := &XList[T]{}
+
+	for _, optSet := range opt {
+		optSet(&newList.opts)
+	}
+
+	return newList
+}
+
+// newTemp : creates an unsynchronized list for internal temporary results.
+// The result has to be handed over with 'adopt' before it leaves the package.
+func newTemp[T comparable]() *XList[T] {
+	return &XList[T]{opts: ListOptions{unsync: true}}
+}
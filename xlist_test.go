@@ -1,15 +1,30 @@
 package xlist
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"iter"
 	"math/rand"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
+// hashedUser : element type with Hasher: pointers to users with the same ID are duplicates
+type hashedUser struct {
+	ID   int
+	Name string
+}
+
+func (u *hashedUser) Hash() uint64                 { return uint64(u.ID) }
+func (u *hashedUser) Equal(other *hashedUser) bool { return u.ID == other.ID }
+
 type teststruct struct {
 	Num int
 	Str string
@@ -92,7 +107,7 @@ func TestXList(t *testing.T) {
 	assert.Nil(t, list.home.prev)
 
 	// size
-	assert.Equal(t, list.size, 1)
+	assert.Equal(t, int64(1), list.size.Load())
 
 	// Clear
 	assert.Equal(t, false, list.IsEmpty())
@@ -569,12 +584,15 @@ func TestXList(t *testing.T) {
 	assert.Equal(t, 5, list.Size())
 	assert.Equal(t, obj5, list.AtPtr(4))
 
+	// pointers are compared by address, not by pointee
 	list.AppendUnique(obj5cp)
-	assert.Equal(t, 5, list.Size())
-	assert.Equal(t, obj5, list.AtPtr(4))
+	assert.Equal(t, 6, list.Size())
+	assert.Equal(t, obj5cp, list.AtPtr(5))
 
+	list.AppendUnique(nil, nil)
+	assert.Equal(t, 7, list.Size())
 	list.AppendUnique(nil)
-	assert.Equal(t, 6, list.Size())
+	assert.Equal(t, 7, list.Size())
 	assert.Equal(t, obj5, list.AtPtr(4))
 
 	// Cycle: 1
@@ -973,7 +991,7 @@ func TestXList(t *testing.T) {
 
 	// All(): invalid positive position should panic
 	assert.Panics(t, func() {
-		for range list.All(WithPos(5)) {
+		for range list.All(WithPos(6)) {
 		}
 	})
 
@@ -1108,7 +1126,7 @@ func TestXList(t *testing.T) {
 
 	// Backward(): invalid positive position should panic
 	assert.Panics(t, func() {
-		for range list.Backward(WithPos(5)) {
+		for range list.Backward(WithPos(6)) {
 		}
 	})
 
@@ -1180,7 +1198,7 @@ func TestXList(t *testing.T) {
 
 	// ValuesBackward(): invalid position should panic
 	assert.Panics(t, func() {
-		for range list.ValuesBackward(WithPos(5)) {
+		for range list.ValuesBackward(WithPos(6)) {
 		}
 	})
 
@@ -1547,3 +1565,1905 @@ func sortTest(t *testing.T) {
 
 	t.Log("Done.")
 }
+
+func TestUnsyncList(t *testing.T) {
+	list := NewWithOptions[int](WithUnsync())
+	list.Append(3, 1, 2)
+	assert.Equal(t, 3, list.Size())
+
+	list.Sort(func(a, b int) bool { return a < b })
+	assert.Equal(t, []int{1, 2, 3}, slicesOf(list))
+
+	// Results inherit the mode of the receiver
+	found := list.Find(func(_ int, v int) bool { return v > 1 })
+	assert.Equal(t, true, found.opts.unsync)
+	assert.Equal(t, []int{2, 3}, slicesOf(found))
+
+	// Temporary lists of synchronized receivers are synchronized
+	syncList := New[int](1, 2, 3)
+	cp, err := syncList.CopyRange(0, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, false, cp.opts.unsync)
+	assert.Equal(t, []int{1, 2}, slicesOf(cp))
+}
+
+// slicesOf : collects list values (test helper)
+func slicesOf[T comparable](list *XList[T]) []T {
+	var result []T
+	for v := range list.Values() {
+		result = append(result, v)
+	}
+	return result
+}
+
+func TestReentrantCalls(t *testing.T) {
+	list := New[int](1, 2, 3)
+
+	// Reads from callbacks run under the lock of the outer operation
+	found := list.Find(func(i int, v int) bool {
+		last, _ := list.LastObject()
+		return v == last || list.Contains(v*10)
+	})
+	assert.Equal(t, []int{3}, slicesOf(found))
+
+	list.Modify(func(i int, v int) int {
+		first, _ := list.At(0)
+		return v + first
+	})
+	assert.Equal(t, []int{2, 4, 5}, slicesOf(list))
+
+	// Mutations from callbacks fail instead of deadlock
+	assert.PanicsWithValue(t, ErrReentrantCall, func() {
+		list.Find(func(_ int, v int) bool {
+			list.Append(v)
+			return true
+		})
+	})
+	assert.PanicsWithValue(t, ErrReentrantCall, func() {
+		for range list.All() {
+			list.MarkAll()
+		}
+	})
+
+	var err error
+	list.ModifyRev(func(i int, v int) int {
+		_, err = list.DeleteAt(i)
+		return v
+	})
+	assert.ErrorIs(t, err, ErrReentrantCall)
+	assert.Equal(t, 3, list.Size())
+
+	// The list is usable after the failed calls
+	list.Append(5)
+	assert.Equal(t, []int{2, 4, 5, 5}, slicesOf(list))
+
+	// Calls of other goroutines wait for the lock, they are not reentrant
+	done := make(chan error)
+	list.Find(func(i int, _ int) bool {
+		if i == 0 {
+			go func() {
+				_, err := list.DeleteAt(0)
+				done <- err
+			}()
+		}
+		return false
+	})
+	assert.Nil(t, <-done)
+	assert.Equal(t, []int{4, 5, 5}, slicesOf(list))
+
+	// Deferred mode: mutations are applied after the outer operation
+	dlist := NewWithOptions[int](WithDeferredReentrancy())
+	dlist.Append(1, 2, 3)
+
+	for i, v := range dlist.All() {
+		if v%2 == 1 {
+			assert.ErrorIs(t, dlist.Insert(0, v*10), ErrDeferred)
+		}
+		assert.Equal(t, 3, dlist.Size(), "index %d", i)
+	}
+	assert.Equal(t, []int{30, 10, 1, 2, 3}, slicesOf(dlist))
+
+	dlist.Modify(func(_ int, v int) int {
+		dlist.Append(v)
+		return v + 1
+	})
+	assert.Equal(t, []int{31, 11, 2, 3, 4, 30, 10, 1, 2, 3}, slicesOf(dlist))
+
+	// Errors of deferred mutations go to the handler, or panic after the outer operation without it
+	var deferredErrs []error
+	hlist := NewWithOptions[int](WithDeferredReentrancy(), WithDeferredErrorHandler(func(err error) {
+		deferredErrs = append(deferredErrs, err)
+	}))
+	hlist.Append(1, 2)
+	hlist.Find(func(i int, _ int) bool {
+		v, err := hlist.DeleteAt(5)
+		assert.ErrorIs(t, err, ErrDeferred)
+		assert.Equal(t, 0, v)
+		return false
+	})
+	assert.Len(t, deferredErrs, 2)
+	assert.ErrorIs(t, deferredErrs[0], ErrInvalidIndex)
+
+	func() {
+		defer func() {
+			err, _ := recover().(error)
+			assert.ErrorIs(t, err, ErrElementNotFound)
+		}()
+
+		dlist.Find(func(i int, _ int) bool {
+			if i == 0 {
+				_ = dlist.Replace(100, 0)
+			}
+			return false
+		})
+	}()
+
+	// A panic of the callback goes on as is, the mutations it queued are dropped
+	size := dlist.Size()
+	assert.PanicsWithValue(t, "callback failed", func() {
+		for range dlist.All() {
+			dlist.Append(1)
+			_ = dlist.Replace(100, 0)
+			panic("callback failed")
+		}
+	})
+	assert.Equal(t, size, dlist.Size())
+}
+
+func TestIterationModes(t *testing.T) {
+	// Locked (default): mutations from the loop body are reentrant calls
+	list := New[int](1, 2, 3)
+	assert.Panics(t, func() {
+		for range list.All(WithLocked()) {
+			list.Append(4)
+		}
+	})
+	assert.Equal(t, []int{1, 2, 3}, slicesOf(list))
+
+	// Snapshot: the loop sees the list as it was at the start
+	var got []int
+	for i, v := range list.All(WithSnapshot()) {
+		got = append(got, v)
+		list.Append(v * 10)
+		_ = list.Replace(i, 0)
+	}
+	assert.Equal(t, []int{1, 2, 3}, got)
+	assert.Equal(t, []int{0, 0, 0, 10, 20, 30}, slicesOf(list))
+
+	got = nil
+	var indexes []int
+	for i, v := range list.Backward(WithSnapshot(), WithPos(4), WithCount(2)) {
+		indexes = append(indexes, i)
+		got = append(got, v)
+		list.Clear()
+	}
+	assert.Equal(t, []int{4, 3}, indexes)
+	assert.Equal(t, []int{20, 10}, got)
+
+	// Live: deleted elements are skipped, inserted ahead are visited, indexes are actual
+	list = New[int](1, 2, 3, 4, 5)
+	got, indexes = nil, nil
+	for i, v := range list.All(WithLive()) {
+		indexes = append(indexes, i)
+		got = append(got, v)
+
+		switch v {
+		case 2:
+			_, _ = list.DeleteAt(i + 1) // 3 is ahead of the cursor
+			_ = list.Insert(0, 0)       // 0 is behind the cursor
+		case 4:
+			_, _ = list.DeleteAt(i) // current element
+			_ = list.Insert(i+1, 6) // insert after 5
+		}
+	}
+	assert.Equal(t, []int{1, 2, 4, 5, 6}, got)
+	assert.Equal(t, []int{0, 1, 3, 3, 4}, indexes)
+	assert.Equal(t, []int{0, 1, 2, 5, 6}, slicesOf(list))
+
+	got = nil
+	for v := range list.ValuesBackward(WithLive(), WithCount(3)) {
+		got = append(got, v)
+		if v == 5 {
+			_, _ = list.DeleteAt(2) // 2 is the next one
+		}
+	}
+	assert.Equal(t, []int{6, 5, 1}, got)
+
+	// Live: iteration stops when the list is cleared
+	got = nil
+	for v := range list.Values(WithLive()) {
+		got = append(got, v)
+		list.Clear()
+	}
+	assert.Equal(t, []int{0}, got)
+
+	// Live: other goroutines can write during the loop
+	list = New[int](1, 2, 3)
+	for _, v := range list.All(WithLive()) {
+		done := make(chan struct{})
+		go func() {
+			list.Append(v)
+			close(done)
+		}()
+		<-done
+		if list.Size() > 5 {
+			break
+		}
+	}
+	assert.Equal(t, []int{1, 2, 3, 1, 2, 3}, slicesOf(list))
+}
+
+func TestFailFastIterators(t *testing.T) {
+	list := New[int](1, 2, 3, 4, 5)
+
+	// Structural modification invalidates the iterator
+	it := list.Iterator()
+	assert.True(t, it.Next())
+	assert.True(t, it.Next())
+	_, _ = list.DeleteAt(0)
+	assert.False(t, it.Next())
+	assert.Equal(t, -1, it.Index())
+	_, ok := it.Value()
+	assert.False(t, ok)
+	assert.ErrorIs(t, it.Err(), ErrConcurrentModification)
+
+	// Invalid until Reset
+	_, ok = it.SetFirst()
+	assert.False(t, ok)
+	it.Reset()
+	assert.Nil(t, it.Err())
+	assert.True(t, it.Next())
+	assert.Equal(t, 0, it.Index())
+
+	// Value modification invalidates the iterator too...
+	assert.Nil(t, list.Replace(1, 20))
+	_, ok = it.NextValue()
+	assert.False(t, ok)
+	assert.ErrorIs(t, it.Err(), ErrConcurrentModification)
+
+	// ...unless value changes are allowed
+	it = list.Iterator().AllowValueChanges()
+	assert.True(t, it.Next())
+	list.Sort(func(a, b int) bool { return a > b })
+	v, ok := it.Value()
+	assert.True(t, ok)
+	assert.Equal(t, 20, v)
+	list.Clear()
+	assert.False(t, it.Next())
+	assert.ErrorIs(t, it.Err(), ErrConcurrentModification)
+
+	// Not positioned iterator is not affected
+	list.Append(1, 2)
+	it = list.Iterator()
+	list.Append(3)
+	assert.True(t, it.Next())
+	assert.True(t, it.Next())
+	assert.True(t, it.Next())
+	assert.False(t, it.Next())
+	assert.Nil(t, it.Err())
+
+	// Fail-fast live range iteration
+	list.Set(1, 2, 3)
+	assert.PanicsWithValue(t, ErrConcurrentModification, func() {
+		for i := range list.All(WithLive(), WithFailFast()) {
+			_, _ = list.DeleteAt(i)
+		}
+	})
+	assert.Equal(t, []int{2, 3}, slicesOf(list))
+
+	assert.PanicsWithValue(t, ErrConcurrentModification, func() {
+		for i, v := range list.Backward(WithLive(), WithFailFast()) {
+			_ = list.Replace(i, v*10)
+		}
+	})
+
+	var got []int
+	for i, v := range list.Backward(WithLive(), WithFailFast(), WithAllowValueChanges()) {
+		_ = list.Replace(i, v+1)
+		got = append(got, v)
+	}
+	assert.Equal(t, []int{30, 2}, got)
+	assert.Equal(t, []int{3, 31}, slicesOf(list))
+}
+
+func TestIteratorEdit(t *testing.T) {
+	list := New[int](1, 2, 3, 4, 5, 6)
+
+	// Filter in place: remove even elements
+	it := list.Iterator()
+	for it.Next() {
+		v, _ := it.Value()
+		if v%2 == 0 {
+			assert.Nil(t, it.Remove())
+			_, ok := it.Value()
+			assert.False(t, ok)
+			assert.ErrorIs(t, it.Remove(), ErrElementNotFound)
+		}
+	}
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []int{1, 3, 5}, slicesOf(list))
+	checkChain(t, list)
+
+	// Set, InsertBefore, InsertAfter keep index and range
+	it = list.Iterator(1, 1)
+	assert.True(t, it.Next())
+	assert.Equal(t, 1, it.Index())
+	assert.Nil(t, it.Set(30))
+	assert.Nil(t, it.InsertBefore(10, 20))
+	assert.Equal(t, 3, it.Index())
+	assert.Nil(t, it.InsertAfter(40))
+	assert.True(t, it.Next())
+	v, _ := it.Value()
+	assert.Equal(t, 40, v)
+	assert.Equal(t, 4, it.Index())
+	assert.False(t, it.Next()) // range end: element 5 is outside
+	assert.Equal(t, []int{1, 10, 20, 30, 40, 5}, slicesOf(list))
+
+	assert.True(t, it.Prev())
+	assert.True(t, it.Prev())
+	assert.True(t, it.Prev())
+	assert.False(t, it.Prev()) // range start
+	v, _ = it.Value()
+	assert.Equal(t, 10, v)
+
+	// Remove, then Prev
+	_, _ = it.SetIndex(3)
+	assert.Nil(t, it.Remove())
+	assert.True(t, it.Prev())
+	v, _ = it.Value()
+	assert.Equal(t, 20, v)
+	assert.Equal(t, 2, it.Index())
+
+	// Remove of the last element
+	it = list.Iterator()
+	_, _ = it.SetLast()
+	assert.Nil(t, it.Remove())
+	assert.False(t, it.Next())
+	assert.Equal(t, []int{1, 10, 20, 40}, slicesOf(list))
+	checkChain(t, list)
+
+	// Marks
+	it = list.Iterator()
+	assert.ErrorIs(t, it.Mark(), ErrElementNotFound)
+	_, _ = it.NextValue()
+	assert.Nil(t, it.Mark())
+	assert.True(t, list.IsMarkedAtIndex(0))
+	assert.Nil(t, it.Unmark())
+	assert.False(t, list.IsMarkedAtIndex(0))
+
+	// Edits of the list by others invalidate the iterator
+	list.Append(7)
+	assert.ErrorIs(t, it.Set(1), ErrConcurrentModification)
+
+	// Reentrant edit
+	list.Find(func(int, int) bool {
+		it.Reset()
+		it.Next()
+		assert.ErrorIs(t, it.Remove(), ErrReentrantCall)
+		return false
+	})
+}
+
+func TestMutableRange(t *testing.T) {
+	list := New[int](1, 2, 3, 4, 5, 6)
+
+	var indexes []int
+	for i, ref := range list.AllMut() {
+		indexes = append(indexes, i)
+		assert.Equal(t, i, ref.Index())
+
+		switch v := ref.Get(); {
+		case v%2 == 0:
+			assert.Nil(t, ref.Delete())
+			assert.ErrorIs(t, ref.Set(0), ErrElementNotFound)
+		case v == 3:
+			assert.Nil(t, ref.InsertAfter(31, 32))
+			assert.Nil(t, ref.Mark())
+		default:
+			assert.Nil(t, ref.Set(v*10))
+		}
+	}
+	assert.Equal(t, []int{0, 1, 1, 4, 4, 5}, indexes)
+	assert.Equal(t, []int{10, 3, 31, 32, 50}, slicesOf(list))
+	assert.True(t, list.IsMarkedAtIndex(1))
+	checkChain(t, list)
+
+	// Backward with range options
+	var got []int
+	var stale *Ref[int]
+	for i, ref := range list.BackwardMut(WithPos(3), WithCount(3)) {
+		got = append(got, ref.Get())
+		assert.Equal(t, ref.IsMarked(), i == 1)
+		assert.Nil(t, ref.Unmark())
+		assert.Nil(t, ref.InsertAfter(i))
+		if i == 2 {
+			assert.Nil(t, ref.Delete())
+			assert.ErrorIs(t, ref.InsertAfter(i), ErrElementNotFound)
+		}
+		stale = ref
+	}
+	assert.Equal(t, []int{32, 31, 3}, got)
+	assert.Equal(t, []int{10, 3, 1, 2, 32, 3, 50}, slicesOf(list))
+	assert.False(t, list.IsMarkedAtIndex(1))
+
+	// Reference is valid during its loop step only
+	assert.ErrorIs(t, stale.Set(1), ErrStaleReference)
+	assert.PanicsWithValue(t, ErrStaleReference, func() { stale.Get() })
+
+	// Other mutations from the loop body are reentrant
+	assert.Panics(t, func() {
+		for range list.AllMut() {
+			list.Append(1)
+		}
+	})
+
+	// Break
+	for _, ref := range list.AllMut() {
+		_ = ref.Delete()
+		break
+	}
+	assert.Equal(t, []int{3, 1, 2, 32, 3, 50}, slicesOf(list))
+}
+
+func TestIteratorBridge(t *testing.T) {
+	list := New[int](1, 2, 3, 4, 5, 6)
+
+	// Iterator.Seq resumes from the current position
+	it := list.Iterator()
+	var got []int
+	for v := range it.Seq() {
+		got = append(got, v)
+		if v == 2 {
+			break
+		}
+	}
+	assert.Equal(t, 1, it.Index())
+
+	for v := range it.Seq() {
+		got = append(got, v)
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, got)
+
+	// Iterator drives range helpers and edits the list during the loop
+	it = list.Iterator(1, 4)
+	var indexes []int
+	for i := range Filter(it.Seq2(), func(_ int, v int) bool { return v%2 == 0 }) {
+		indexes = append(indexes, i)
+		assert.Nil(t, it.Set(0))
+	}
+	assert.Equal(t, []int{1, 3}, indexes)
+	assert.Equal(t, []int{1, 0, 3, 0, 5, 6}, slicesOf(list))
+
+	// Modification of the list by others stops the loop
+	it = list.Iterator()
+	got = nil
+	for v := range it.Seq() {
+		got = append(got, v)
+		list.Append(7)
+	}
+	assert.Equal(t, []int{1}, got)
+	assert.ErrorIs(t, it.Err(), ErrConcurrentModification)
+
+	// range loop paused and resumed as an iterator
+	pit := Pull2(list.All(WithSnapshot()))
+	assert.Equal(t, -1, pit.Index())
+	assert.True(t, pit.Next())
+	v, ok := pit.Value()
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	list.Append(8) // snapshot: the list is not locked while paused
+
+	v, ok = pit.NextValue()
+	assert.True(t, ok)
+	assert.Equal(t, 0, v)
+	assert.Equal(t, 1, pit.Index())
+
+	indexes = nil
+	for i := range TakeWhile(pit.Seq2(), func(_ int, v int) bool { return v != 6 }) {
+		indexes = append(indexes, i)
+	}
+	assert.Equal(t, []int{2, 3, 4}, indexes)
+	v, _ = pit.Value()
+	assert.Equal(t, 6, v) // TakeWhile pulled 6 and stopped
+
+	got = nil
+	for v := range pit.Seq() {
+		got = append(got, v)
+	}
+	assert.Equal(t, []int{7}, got)
+	assert.False(t, pit.Next())
+	pit.Stop()
+
+	// Pulled range loop in locked mode yields a copy: the list is not locked while paused
+	pit = Pull2(list.All())
+	_, _ = pit.NextValue()
+	v, _ = list.At(0)
+	assert.Equal(t, 1, v)
+	list.Append(9) // doesn't wait for Stop
+	n := 1
+	for range pit.Seq() {
+		n++
+	}
+	assert.Equal(t, list.Size()-1, n) // the copy was taken before Append
+	_, ok = pit.NextValue()
+	assert.False(t, ok)
+
+	vit := Pull(list.Values())
+	first, _ := vit.NextValue()
+	list.Append(first)
+	vit.Stop()
+	last, _ := list.Last()
+	assert.Equal(t, first, last)
+
+	next, stop := iter.Pull(list.Values())
+	v, _ = next()
+	list.Append(v)
+	stop()
+
+	// AllMut can't be pulled
+	assert.PanicsWithValue(t, ErrPulledLoop, func() {
+		mit := Pull2(list.AllMut())
+		defer mit.Stop()
+		mit.Next()
+	})
+
+	// FromPull with iter.Pull functions
+	pit2 := FromPull(iter.Pull(Map(list.Values(), func(v int) string { return fmt.Sprint(v) })))
+	defer pit2.Stop()
+	s, _ := pit2.NextValue()
+	assert.Equal(t, "1", s)
+	s, _ = pit2.NextValue()
+	assert.Equal(t, "0", s)
+	assert.Equal(t, 1, pit2.Index())
+}
+
+func TestPagination(t *testing.T) {
+	list := New[int]()
+	for i := range 10 {
+		list.Append(i)
+	}
+
+	_, err := list.Page("", 0)
+	assert.ErrorIs(t, err, ErrInvalidRange)
+
+	// Forward pass
+	var pages [][]int
+	var prevs []string
+	cursor := ""
+	for {
+		page, err := list.Page(cursor, 4)
+		assert.Nil(t, err)
+		pages = append(pages, page.Items)
+		prevs = append(prevs, page.Prev)
+		if page.Next == "" {
+			break
+		}
+		cursor = page.Next
+	}
+	assert.Equal(t, [][]int{{0, 1, 2, 3}, {4, 5, 6, 7}, {8, 9}}, pages)
+	assert.Equal(t, "", prevs[0])
+
+	// Backward from the last page
+	page, err := list.Page(prevs[2], 4)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{4, 5, 6, 7}, page.Items)
+	page, err = list.Page(page.Prev, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3}, page.Items)
+	assert.NotEqual(t, "", page.Prev)
+	page, err = list.Page(page.Prev, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0}, page.Items)
+	assert.Equal(t, "", page.Prev)
+
+	// Cursor survives inserts and deletes elsewhere
+	page, _ = list.Page("", 3)
+	next := page.Next
+	_ = list.Insert(0, -1)
+	_, _ = list.DeleteAt(5) // 4
+	_ = list.Insert(3, 100) // before cursor element 2 -> -1 0 1 100 2 3 5
+	page, err = list.Page(next, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{3, 5, 6}, page.Items)
+
+	// Deleted cursor element fails
+	deleted := page.Prev    // before 3
+	_, _ = list.DeleteAt(5) // 3
+	_, err = list.Page(deleted, 3)
+	assert.ErrorIs(t, err, ErrCursorNotFound)
+
+	page, _ = list.Page("", 2)
+	list.Clear()
+	_, err = list.Page(page.Next, 2)
+	assert.ErrorIs(t, err, ErrCursorNotFound)
+
+	// Foreign and broken cursors
+	list.Append(1, 2, 3)
+	other := New[int](1, 2, 3)
+	page, _ = other.Page("", 1)
+	_, err = list.Page(page.Next, 1)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+	_, err = list.Page("not a cursor", 1)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	// Splice moves elements to another list: their cursors are not valid in the source any more
+	page, _ = list.Page("", 1)
+	other.Splice(list)
+	_, err = list.Page(page.Next, 1)
+	assert.ErrorIs(t, err, ErrCursorNotFound)
+
+	// Empty list
+	page, err = list.Page("", 5)
+	assert.Nil(t, err)
+	assert.Nil(t, page.Items)
+	assert.Equal(t, "", page.Next)
+}
+
+func TestStream(t *testing.T) {
+	list := New[int](5, 3, 8, 3, 1, 9, 8, 2)
+
+	// Lazy: nothing is pulled until a terminal runs, Limit stops pulling
+	pulled := 0
+	s := list.Stream().
+		Peek(func(int) { pulled++ }).
+		Filter(func(v int) bool { return v > 2 }).
+		Limit(3)
+	assert.Equal(t, 0, pulled)
+	assert.Equal(t, []int{5, 3, 8}, s.ToSlice())
+	assert.Equal(t, 3, pulled)
+
+	assert.Equal(t, []int{1, 2, 3, 5, 8, 9},
+		Distinct(list.Stream()).Sorted(func(a, b int) bool { return a < b }).ToSlice())
+	assert.Equal(t, []int{2, 8, 9, 1}, list.Stream().Reverse().Limit(4).ToSlice())
+	assert.Equal(t, []int{3, 1}, list.Stream().Skip(3).Limit(2).ToSlice())
+	assert.Nil(t, list.Stream().Limit(0).ToSlice())
+	assert.Equal(t, 8, list.Stream().Count())
+
+	// Stable sort
+	pairs := New[string]("b1", "a1", "b2", "a2")
+	assert.Equal(t, []string{"a1", "a2", "b1", "b2"},
+		pairs.Stream().Sorted(func(a, b string) bool { return a[0] < b[0] }).ToSlice())
+
+	v, ok := list.Stream().Filter(func(v int) bool { return v > 8 }).First()
+	assert.True(t, ok)
+	assert.Equal(t, 9, v)
+	_, ok = list.Stream().Filter(func(v int) bool { return v > 10 }).First()
+	assert.False(t, ok)
+
+	sum, ok := list.Stream().Reduce(func(acc, v int) int { return acc + v })
+	assert.True(t, ok)
+	assert.Equal(t, 39, sum)
+	_, ok = New[int]().Stream().Reduce(func(acc, v int) int { return acc + v })
+	assert.False(t, ok)
+
+	var each []int
+	list.Stream(WithPos(6)).ForEach(func(v int) { each = append(each, v) })
+	assert.Equal(t, []int{8, 2}, each)
+
+	// Type-changing step and Collect
+	strs := Collect(MapStream(Distinct(list.Stream()), func(v int) string { return fmt.Sprint(v) }))
+	assert.Equal(t, []string{"5", "3", "8", "1", "9", "2"}, slicesOf(strs))
+
+	// Values of a stream don't have to be comparable
+	lens := MapStream(list.Stream().Limit(3), func(v int) []int { return make([]int, v) }).
+		Filter(func(s []int) bool { return len(s) > 3 }).ToSlice()
+	assert.Equal(t, [][]int{make([]int, 5), make([]int, 8)}, lens)
+
+	// Stream of any sequence; snapshot mode lets terminal callbacks modify the list
+	StreamOf(list.Values(WithSnapshot())).ForEach(func(v int) { list.Append(v) })
+	assert.Equal(t, 16, list.Size())
+}
+
+func TestAggregates(t *testing.T) {
+	list := New[int](5, 3, 8, 3, 1, 9, 8, 2)
+	empty := New[int]()
+
+	sum, ok := Reduce(list.Values(), func(acc, v int) int { return acc + v })
+	assert.True(t, ok)
+	assert.Equal(t, 39, sum)
+	_, ok = Reduce(empty.Values(), func(acc, v int) int { return acc + v })
+	assert.False(t, ok)
+
+	assert.Equal(t, "53831982", Fold(list.Values(), "", func(acc string, v int) string { return acc + fmt.Sprint(v) }))
+	assert.Equal(t, 7, Fold2(list.All(), 0, func(acc, i, v int) int { return max(acc, i) }))
+
+	assert.Equal(t, 8, Count(list.Values()))
+	assert.Equal(t, 0, Count(empty.Values()))
+	assert.Equal(t, 3, CountFunc(list.Values(), func(v int) bool { return v%2 == 0 }))
+	assert.Equal(t, 2, CountFunc2(list.All(), func(i, v int) bool { return i < 4 && v == 3 }))
+
+	// Min, Max and the first extremum with custom order
+	m, ok := Min(list.Values())
+	assert.True(t, ok)
+	assert.Equal(t, 1, m)
+	m, _ = Max(list.Values())
+	assert.Equal(t, 9, m)
+	_, ok = Max(empty.Values())
+	assert.False(t, ok)
+
+	words := New[string]("bb", "a", "cc", "d")
+	byLen := func(a, b string) bool { return len(a) < len(b) }
+	w, _ := MinFunc(words.Values(), byLen)
+	assert.Equal(t, "a", w)
+	w, _ = MaxFunc(words.Values(), byLen)
+	assert.Equal(t, "bb", w)
+	i, w, ok := MaxFunc2(words.All(), byLen)
+	assert.True(t, ok)
+	assert.Equal(t, 0, i)
+	assert.Equal(t, "bb", w)
+	i, w, _ = MinFunc2(words.Backward(), byLen)
+	assert.Equal(t, 3, i)
+	assert.Equal(t, "d", w)
+	i, _, ok = MinFunc2(New[string]().All(), byLen)
+	assert.False(t, ok)
+	assert.Equal(t, -1, i)
+
+	// Sum and Average
+	assert.Equal(t, 39, Sum(list.Values()))
+	avg, ok := Average(list.Values())
+	assert.True(t, ok)
+	assert.Equal(t, 4.875, avg)
+	_, ok = Average(empty.Values())
+	assert.False(t, ok)
+	small := New[int8](100, 100, 100)
+	avg, _ = Average(small.Values())
+	assert.Equal(t, 100.0, avg)
+
+	// First and Last
+	v, ok := First(list.Values(WithPos(2)))
+	assert.True(t, ok)
+	assert.Equal(t, 8, v)
+	v, _ = Last(list.Values())
+	assert.Equal(t, 2, v)
+	_, ok = First(empty.Values())
+	assert.False(t, ok)
+	i, v, ok = First2(Filter(list.All(), func(_, v int) bool { return v > 5 }))
+	assert.True(t, ok)
+	assert.Equal(t, []int{2, 8}, []int{i, v})
+	i, v, _ = Last2(Filter(list.All(), func(_, v int) bool { return v == 3 }))
+	assert.Equal(t, []int{3, 3}, []int{i, v})
+	i, _, ok = Last2(empty.All())
+	assert.False(t, ok)
+	assert.Equal(t, -1, i)
+
+	// Scan: running sums, stops with the loop
+	var sums []int
+	for s := range Scan(list.Values(), 0, func(acc, v int) int { return acc + v }) {
+		if s > 20 {
+			break
+		}
+		sums = append(sums, s)
+	}
+	assert.Equal(t, []int{5, 8, 16, 19, 20}, sums)
+
+	// Methods of the list
+	sum, _ = list.Reduce(func(acc, v int) int { return acc + v })
+	assert.Equal(t, 39, sum)
+	assert.Equal(t, 2, list.CountFunc(func(v int) bool { return v == 8 }))
+	m, _ = list.MinFunc(func(a, b int) bool { return a < b })
+	assert.Equal(t, 1, m)
+	m, _ = list.MaxFunc(func(a, b int) bool { return a < b })
+	assert.Equal(t, 9, m)
+	v, _ = list.First()
+	assert.Equal(t, 5, v)
+	v, _ = list.Last()
+	assert.Equal(t, 2, v)
+	_, ok = empty.Reduce(func(acc, v int) int { return acc + v })
+	assert.False(t, ok)
+	_, ok = empty.MinFunc(func(a, b int) bool { return a < b })
+	assert.False(t, ok)
+	_, ok = empty.First()
+	assert.False(t, ok)
+	_, ok = empty.Last()
+	assert.False(t, ok)
+
+	// Callbacks can read the list, mutations fail
+	n := list.CountFunc(func(v int) bool { return v < list.Size() })
+	assert.Equal(t, 5, n)
+	assert.Panics(t, func() {
+		list.CountFunc(func(v int) bool { list.Append(v); return true })
+	})
+}
+
+func TestCombinators(t *testing.T) {
+	a := New[int](1, 2, 3)
+	b := New[string]("x", "y")
+	empty := New[int]()
+
+	// Zip stops at the shorter source, ZipLongest marks missing values
+	var zipped []string
+	for n, s := range Zip(a.Values(), b.Values()) {
+		zipped = append(zipped, fmt.Sprint(n, s))
+	}
+	assert.Equal(t, []string{"1x", "2y"}, zipped)
+
+	var longest []Zipped[int, string]
+	for z := range ZipLongest(a.ValuesBackward(), b.Values()) {
+		longest = append(longest, z)
+	}
+	assert.Equal(t, []Zipped[int, string]{
+		{V1: 3, Ok1: true, V2: "x", Ok2: true},
+		{V1: 2, Ok1: true, V2: "y", Ok2: true},
+		{V1: 1, Ok1: true},
+	}, longest)
+
+	// A list with itself: pulled sources copy their ranges, the loop body may modify the list
+	var diffs []int
+	for x, y := range Zip(a.Values(), a.Values(WithPos(1))) {
+		diffs = append(diffs, y-x)
+	}
+	assert.Equal(t, []int{1, 1}, diffs)
+
+	d := New[int](1, 2, 3)
+	for x, y := range Zip(Map(d.Values(), func(v int) int { return v * 10 }), d.Values()) {
+		d.Append(x + y)
+	}
+	assert.Equal(t, []int{1, 2, 3, 11, 22, 33}, slicesOf(d))
+
+	// Concat, Interleave, Enumerate
+	assert.Equal(t, []int{1, 2, 3, 3, 2, 1}, slices.Collect(Concat(a.Values(), empty.Values(), a.ValuesBackward())))
+	var idx []int
+	for i := range Concat2(a.All(), a.Backward()) {
+		idx = append(idx, i)
+	}
+	assert.Equal(t, []int{0, 1, 2, 2, 1, 0}, idx)
+
+	c := New[int](10, 20, 30, 40)
+	assert.Equal(t, []int{1, 10, 2, 20, 3, 30, 40}, slices.Collect(Interleave(a.Values(), empty.Values(), c.Values())))
+	assert.Equal(t, []int{1, 10}, StreamOf(Interleave(a.Values(), c.Values())).Limit(2).ToSlice())
+
+	evens := Filter(Enumerate(c.ValuesBackward()), func(i, _ int) bool { return i%2 == 0 })
+	assert.Equal(t, []int{40, 20}, slices.Collect(ToValues(evens)))
+
+	// Pairwise, Window
+	var pairs [][2]int
+	for x, y := range Pairwise(c.Values()) {
+		pairs = append(pairs, [2]int{x, y})
+	}
+	assert.Equal(t, [][2]int{{10, 20}, {20, 30}, {30, 40}}, pairs)
+	assert.Equal(t, 0, Count(ToValues(Pairwise(New[int](1).Values()))))
+
+	windows := slices.Collect(Window(c.Values(), 3))
+	assert.Equal(t, [][]int{{10, 20, 30}, {20, 30, 40}}, windows)
+	assert.Nil(t, slices.Collect(Window(a.Values(), 4)))
+	assert.Panics(t, func() { Window(a.Values(), 0) })
+
+	// FlatMap, Flatten, FlattenLists
+	dup := FlatMap(a.Values(), func(v int) iter.Seq[int] { return Repeat(v, v) })
+	assert.Equal(t, []int{1, 2, 2, 3, 3, 3}, slices.Collect(dup))
+	assert.Equal(t, []int{1, 2, 3, 10, 20, 30, 40},
+		slices.Collect(Flatten(slices.Values([]iter.Seq[int]{a.Values(), c.Values()}))))
+
+	lists := New[*XList[int]](a, nil, empty, c)
+	assert.Equal(t, []int{1, 2, 3, 10, 20, 30, 40}, slices.Collect(FlattenLists(lists.Values())))
+	assert.Equal(t, []int{1, 10}, slices.Collect(FlattenLists(lists.Values(), WithCount(1))))
+
+	// Cycle, Repeat
+	var cycled []int
+	for v := range Cycle(a.Values()) {
+		if len(cycled) == 7 {
+			break
+		}
+		cycled = append(cycled, v)
+	}
+	assert.Equal(t, []int{1, 2, 3, 1, 2, 3, 1}, cycled)
+	assert.Nil(t, slices.Collect(Cycle(empty.Values())))
+	assert.Equal(t, 5, Count(Repeat("a", 5)))
+	assert.Equal(t, []int{7, 7}, StreamOf(Repeat(7, -1)).Limit(2).ToSlice())
+
+	// Map2 and Filter2 keep the keys
+	labels := Map2(c.All(), func(i, v int) string { return fmt.Sprintf("%d:%d", i, v) })
+	var got []string
+	for i, s := range Filter2(labels, func(i int, _ string) bool { return i > 1 }) {
+		got = append(got, fmt.Sprint(i, "=", s))
+	}
+	assert.Equal(t, []string{"2=2:30", "3=3:40"}, got)
+
+	grows := Filter2(Pairwise(New[int](1, 3, 2, 5).Values()), func(x, y int) bool { return y > x })
+	assert.Equal(t, 2, Count(ToValues(grows)))
+}
+
+func TestBatches(t *testing.T) {
+	list := New[int](1, 2, 3, 4, 5, 6, 7)
+
+	assert.Equal(t, [][]int{{1, 2, 3}, {4, 5, 6}, {7}}, slices.Collect(Chunk(list.Values(), 3)))
+	assert.Equal(t, [][]int{{7, 6, 5, 4, 3, 2, 1}}, slices.Collect(Chunk(list.ValuesBackward(), 10)))
+	assert.Nil(t, slices.Collect(Chunk(New[int]().Values(), 2)))
+	assert.Panics(t, func() { Chunk(list.Values(), 0) })
+
+	// ChunkBy groups consecutive values only
+	words := New[string]("apple", "avocado", "banana", "blueberry", "cherry", "apricot")
+	var groups []string
+	for k, g := range ChunkBy(words.Values(), func(s string) byte { return s[0] }) {
+		groups = append(groups, fmt.Sprint(string(k), len(g)))
+	}
+	assert.Equal(t, []string{"a2", "b2", "c1", "a1"}, groups)
+
+	// ForEachBatch stops on the first error and reports succeeded batches
+	errTest := errors.New("test")
+	var seen [][]int
+	err := list.ForEachBatch(2, func(batch []int) error {
+		if batch[0] == 5 {
+			return errTest
+		}
+		seen = append(seen, batch)
+		return nil
+	})
+	var be *BatchError
+	assert.ErrorAs(t, err, &be)
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, 2, be.Done)
+	assert.Equal(t, "batch 2: test", err.Error())
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}}, seen)
+
+	assert.Nil(t, list.ForEachBatch(3, func([]int) error { return nil }, WithPos(2)))
+	assert.ErrorIs(t, list.ForEachBatch(0, func([]int) error { return nil }), ErrInvalidRange)
+	assert.ErrorIs(t, list.ForEachBatch(3, func([]int) error { return nil }, WithPos(20)), ErrInvalidIndex)
+
+	// Snapshot mode lets the callback modify the list
+	err = list.ForEachBatch(4, func(batch []int) error {
+		list.Append(batch[0])
+		return nil
+	}, WithSnapshot())
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 1, 5}, slicesOf(list))
+
+	// Parallel: results keep the order of batches
+	big := New[int]()
+	for i := range 1000 {
+		big.Append(i)
+	}
+	sums, err := MapBatches(big.Values(), 10, 8, func(batch []int) (int, error) {
+		time.Sleep(time.Duration(rand.Intn(100)) * time.Microsecond)
+		return Sum(slices.Values(batch)), nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 100, len(sums))
+	for i, s := range sums {
+		assert.Equal(t, 100*i+45, s)
+	}
+
+	// Parallel error: the prefix before the first failed batch is reported
+	sums, err = MapBatches(big.Values(), 10, 8, func(batch []int) (int, error) {
+		if batch[0] == 370 || batch[0] == 550 {
+			return 0, errTest
+		}
+		return batch[0], nil
+	})
+	assert.ErrorAs(t, err, &be)
+	assert.Equal(t, 37, be.Done)
+	assert.Equal(t, 37, len(sums))
+	assert.Equal(t, 360, sums[36])
+
+	// No batch is started after a failure
+	calls := 0
+	_, err = MapBatches(big.Values(), 10, 1, func(batch []int) (int, error) {
+		calls++
+		return 0, errTest
+	})
+	assert.ErrorAs(t, err, &be)
+	assert.Equal(t, 1, calls)
+
+	// A panic of 'fn' is re-raised in the calling goroutine after the workers are done
+	assert.PanicsWithValue(t, "batch 20", func() {
+		_, _ = MapBatches(big.Values(), 10, 4, func(batch []int) (int, error) {
+			if batch[0] == 200 {
+				panic("batch 20")
+			}
+			return 0, nil
+		})
+	})
+
+	var mtx sync.Mutex
+	total := 0
+	err = big.ForEachBatchParallel(100, 0, func(batch []int) error {
+		mtx.Lock()
+		defer mtx.Unlock()
+		total += len(batch)
+		big.Append(-1) // snapshot mode by default
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1000, total)
+	assert.Equal(t, 1010, big.Size())
+
+	err = big.ForEachBatchParallel(100, 4, func(batch []int) error {
+		if batch[0] == 0 {
+			return errTest
+		}
+		return nil
+	})
+	assert.ErrorAs(t, err, &be)
+	assert.Equal(t, 0, be.Done)
+}
+
+func TestTransforms(t *testing.T) {
+	list := New[int](1, 2, 3, 4, 5, 6)
+
+	strs := MapList(list, func(v int) string { return fmt.Sprint("#", v) })
+	assert.Equal(t, []string{"#1", "#2", "#3", "#4", "#5", "#6"}, slicesOf(strs))
+	assert.Equal(t, 0, MapList(New[int](), func(v int) string { return "" }).Size())
+
+	// Result lists get options of the source
+	unsync := NewWithOptions[int](WithUnsync())
+	unsync.Append(1, 2)
+	assert.True(t, MapList(unsync, func(v int) int64 { return int64(v) }).opts.unsync)
+	assert.False(t, strs.opts.unsync)
+
+	errOdd := errors.New("odd")
+	halves, err := MapListErr(list, func(v int) (int, error) {
+		if v == 3 {
+			return 0, errOdd
+		}
+		return v / 2, nil
+	})
+	assert.ErrorIs(t, err, errOdd)
+	assert.Nil(t, halves)
+	halves, err = MapListErr(list, func(v int) (int, error) { return v / 2, nil })
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0, 1, 1, 2, 2, 3}, slicesOf(halves))
+
+	evens := FilterMap(list, func(v int) (string, bool) { return fmt.Sprint(v), v%2 == 0 })
+	assert.Equal(t, []string{"2", "4", "6"}, slicesOf(evens))
+
+	groups := GroupBy(list, func(v int) int { return v % 3 })
+	assert.Equal(t, 3, len(groups))
+	assert.Equal(t, []int{3, 6}, slicesOf(groups[0]))
+	assert.Equal(t, []int{1, 4}, slicesOf(groups[1]))
+	groups[2].Append(8) // groups are ordinary lists
+	assert.Equal(t, []int{2, 5, 8}, slicesOf(groups[2]))
+
+	squares := ToMap(list, func(v int) (int, int) { return v, v * v })
+	assert.Equal(t, map[int]int{1: 1, 2: 4, 3: 9, 4: 16, 5: 25, 6: 36}, squares)
+
+	people := New[teststruct](teststruct{1, "a"}, teststruct{2, "b"}, teststruct{1, "c"})
+	byNum := IndexBy(people, func(v teststruct) int { return v.Num })
+	assert.Equal(t, map[int]teststruct{1: {1, "c"}, 2: {2, "b"}}, byNum)
+
+	yes, no := Partition(list, func(v int) bool { return v > 4 })
+	assert.Equal(t, []int{5, 6}, slicesOf(yes))
+	assert.Equal(t, []int{1, 2, 3, 4}, slicesOf(no))
+
+	// Callbacks can read the source, mutations fail
+	assert.Equal(t, 6, MapList(list, func(int) int { return list.Size() }).Size())
+	assert.Panics(t, func() {
+		Partition(list, func(v int) bool { list.Append(v); return true })
+	})
+	assert.Equal(t, 6, list.Size())
+}
+
+func TestParallelBulk(t *testing.T) {
+	list := New[int]()
+	for i := range 10000 {
+		list.Append(i)
+	}
+
+	// Results keep list order, whatever the worker split is
+	for _, opt := range [][]func(*BulkOptions){
+		nil,
+		{WithWorkers(1)},
+		{WithWorkers(7), WithMinSegment(1)},
+		{WithWorkers(64), WithMinSegment(100)},
+	} {
+		found := list.ParallelFind(func(i, v int) bool {
+			assert.Equal(t, i, v)
+			return v%3 == 0
+		}, opt...)
+		assert.Equal(t, 3334, found.Size())
+		assert.True(t, slices.IsSorted(slicesOf(found)))
+	}
+
+	list.ParallelModify(func(i, v int) int { return v * 2 }, WithMinSegment(1))
+	v, _ := list.Last()
+	assert.Equal(t, 19998, v)
+	v, _ = list.At(5)
+	assert.Equal(t, 10, v)
+
+	var sum atomic.Int64
+	var calls atomic.Int32
+	list.ParallelForEach(func(i, v int) {
+		sum.Add(int64(v))
+		calls.Add(1)
+	}, WithWorkers(4), WithMinSegment(10))
+	assert.Equal(t, int64(99990000), sum.Load())
+	assert.Equal(t, int32(10000), calls.Load())
+
+	// Empty and short lists
+	assert.Equal(t, 0, New[int]().ParallelFind(func(int, int) bool { return true }).Size())
+	short := New[int](1, 2, 3)
+	short.ParallelModify(func(_, v int) int { return -v }, WithMinSegment(1))
+	assert.Equal(t, []int{-1, -2, -3}, slicesOf(short))
+
+	// A panic in a worker reaches the caller, the list is unlocked after it
+	assert.PanicsWithValue(t, "boom", func() {
+		list.ParallelForEach(func(i, v int) {
+			if i == 7777 {
+				panic("boom")
+			}
+		}, WithWorkers(4), WithMinSegment(1))
+	})
+	list.Append(1)
+	assert.Equal(t, 10001, list.Size())
+
+	// Workers can read the list, mutations fail or are deferred
+	assert.Panics(t, func() {
+		short.ParallelForEach(func(_, v int) { short.Append(v) }, WithMinSegment(1))
+	})
+	assert.Equal(t, 3, short.ParallelFind(func(_, v int) bool { return short.Contains(v) }, WithMinSegment(1)).Size())
+
+	deferred := NewWithOptions[int](WithDeferredReentrancy())
+	deferred.Append(1, 2, 3, 4)
+	deferred.ParallelForEach(func(_, v int) { deferred.Append(v * 10) }, WithWorkers(4), WithMinSegment(1))
+	assert.Equal(t, []int{1, 2, 3, 4, 10, 20, 30, 40}, slicesOf(deferred))
+
+	unsync := NewWithOptions[int](WithUnsync(), WithDeferredReentrancy())
+	unsync.Append(1, 2, 3, 4)
+	unsync.ParallelForEach(func(_, v int) { unsync.Append(v) }, WithMinSegment(1))
+	assert.Equal(t, 8, unsync.Size())
+	unsync.ParallelModify(func(_, v int) int { return v + unsync.Size() }, WithMinSegment(1))
+	v, _ = unsync.At(0)
+	assert.Equal(t, 9, v)
+}
+
+func TestBulkCtx(t *testing.T) {
+	list := New[int](1, 2, 3, 4, 5)
+	errBad := errors.New("bad")
+	ctx := context.Background()
+
+	// ForEachCtx stops on error and reports the index
+	var seen []int
+	err := list.ForEachCtx(ctx, func(i, v int) error {
+		if v == 4 {
+			return errBad
+		}
+		seen = append(seen, v)
+		return nil
+	})
+	var be *BulkError
+	assert.ErrorAs(t, err, &be)
+	assert.ErrorIs(t, err, errBad)
+	assert.Equal(t, 3, be.Index)
+	assert.Equal(t, "index 3: bad", err.Error())
+	assert.Equal(t, []int{1, 2, 3}, seen)
+	assert.Nil(t, list.ForEachCtx(ctx, func(int, int) error { return nil }))
+
+	// Cancelled context stops between callbacks
+	cctx, cancel := context.WithCancel(ctx)
+	calls := 0
+	err = list.ForEachCtx(cctx, func(i, v int) error {
+		calls++
+		if i == 1 {
+			cancel()
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorAs(t, err, &be)
+	assert.Equal(t, 2, be.Index)
+	assert.Equal(t, 2, calls)
+
+	tctx, tcancel := context.WithTimeout(ctx, time.Millisecond)
+	defer tcancel()
+	err = list.ForEachCtx(tctx, func(int, int) error {
+		time.Sleep(2 * time.Millisecond)
+		return nil
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// FindCtx returns elements found before the stop
+	found, err := list.FindCtx(ctx, func(i, v int) (bool, error) { return v%2 == 1, nil })
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 3, 5}, slicesOf(found))
+	found, err = list.FindCtx(ctx, func(i, v int) (bool, error) {
+		if v == 4 {
+			return true, errBad
+		}
+		return v%2 == 1, nil
+	})
+	assert.ErrorIs(t, err, errBad)
+	assert.Equal(t, []int{1, 3}, slicesOf(found))
+
+	// ModifyCtx keeps partial changes by default and restores them with WithRollback
+	failAt3 := func(i, v int) (int, error) {
+		if i == 3 {
+			return 0, errBad
+		}
+		return v * 10, nil
+	}
+	err = list.ModifyCtx(ctx, failAt3)
+	assert.ErrorAs(t, err, &be)
+	assert.Equal(t, 3, be.Index)
+	assert.Equal(t, []int{10, 20, 30, 4, 5}, slicesOf(list))
+
+	list.Set(1, 2, 3, 4, 5)
+	it := list.Iterator()
+	it.Next()
+	err = list.ModifyCtx(ctx, failAt3, WithRollback())
+	assert.ErrorIs(t, err, errBad)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, slicesOf(list))
+	it.Next() // rolled back modification is not a change for iterators
+	assert.Nil(t, it.Err())
+
+	cctx, cancel = context.WithCancel(ctx)
+	cancel()
+	err = list.ModifyCtx(cctx, func(_, v int) (int, error) { return -v, nil }, WithRollback())
+	assert.ErrorAs(t, err, &be)
+	assert.Equal(t, 0, be.Index)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, slicesOf(list))
+
+	assert.Nil(t, list.ModifyCtx(ctx, func(_, v int) (int, error) { return -v, nil }, WithRollback()))
+	assert.Equal(t, []int{-1, -2, -3, -4, -5}, slicesOf(list))
+	it.Next()
+	assert.ErrorIs(t, it.Err(), ErrConcurrentModification)
+
+	// Reentrant call from a callback
+	err = list.ForEachCtx(ctx, func(int, int) error {
+		return list.ModifyCtx(ctx, func(_, v int) (int, error) { return v, nil })
+	})
+	assert.ErrorIs(t, err, ErrReentrantCall)
+}
+
+func TestRangeScope(t *testing.T) {
+	newList := func() *XList[int] { return New[int](9, 8, 7, 6, 5, 4, 3, 2, 1, 0) }
+	asc := func(a, b int) bool { return a < b }
+
+	list := newList()
+	assert.Nil(t, list.Range(2, 6).Sort(asc))
+	assert.Equal(t, []int{9, 8, 3, 4, 5, 6, 7, 2, 1, 0}, slicesOf(list))
+	assert.Nil(t, list.Range(7, -1).Reverse())
+	assert.Equal(t, []int{9, 8, 3, 4, 5, 6, 7, 0, 1, 2}, slicesOf(list))
+	list.Reverse()
+	assert.Equal(t, []int{2, 1, 0, 7, 6, 5, 4, 3, 8, 9}, slicesOf(list))
+
+	// Find and Modify get list indexes
+	found, err := list.Range(3, 5).Find(func(i, v int) bool { return i != 4 })
+	assert.Nil(t, err)
+	assert.Equal(t, []int{7, 5}, slicesOf(found))
+
+	list = newList()
+	var order []int
+	assert.Nil(t, list.Range(1, 3).Modify(func(i, v int) int { order = append(order, i); return v * 10 }))
+	assert.Nil(t, list.Range(7, 8).ModifyRev(func(i, v int) int { order = append(order, i); return -v }))
+	assert.Equal(t, []int{1, 2, 3, 8, 7}, order)
+	assert.Equal(t, []int{9, 80, 70, 60, 5, 4, 3, -2, -1, 0}, slicesOf(list))
+
+	// Marks
+	assert.Nil(t, list.Range(8, 9).MarkAll())
+	assert.Nil(t, list.Range(0, -1).MarkAll())
+	assert.Nil(t, list.Range(1, 8).UnmarkAll())
+	var marked []int
+	for i := range list.All(WithMarkedOnly()) {
+		marked = append(marked, i)
+	}
+	assert.Equal(t, []int{0, 9}, marked)
+
+	// Contains
+	ok, err := list.Range(1, 3).Contains(80, 60)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	ok, _ = list.Range(1, 3).Contains(80, 9)
+	assert.False(t, ok)
+	ok, _ = list.Range(5, -1).ContainsSome(80, 0)
+	assert.True(t, ok)
+	ok, _ = list.Range(5, -1).ContainsSome()
+	assert.False(t, ok)
+
+	// Delete
+	n, err := list.Range(2, 7).Delete()
+	assert.Nil(t, err)
+	assert.Equal(t, 6, n)
+	assert.Equal(t, []int{9, 80, -1, 0}, slicesOf(list))
+	n, _ = list.Range(0, -1).Delete()
+	assert.Equal(t, 4, n)
+	assert.True(t, list.IsEmpty())
+	n, err = list.Range(0, -1).Delete() // whole range of empty list
+	assert.Nil(t, err)
+	assert.Equal(t, 0, n)
+
+	// Invalid ranges; the scope is resolved by each call
+	list = newList()
+	scope := list.Range(5, 9)
+	for _, bad := range []*Scope[int]{list.Range(-1, 3), list.Range(3, 10), list.Range(5, 4), list.Range(10, -1)} {
+		_, err = bad.Find(func(int, int) bool { return true })
+		assert.ErrorIs(t, err, ErrInvalidIndex)
+		assert.ErrorIs(t, bad.Sort(asc), ErrInvalidIndex)
+		_, err = bad.Delete()
+		assert.ErrorIs(t, err, ErrInvalidIndex)
+	}
+	assert.Nil(t, scope.Sort(asc))
+	_, _ = list.DeleteLast()
+	assert.ErrorIs(t, scope.Sort(asc), ErrInvalidIndex)
+
+	// Mutations from callbacks
+	err = list.Range(0, 2).Modify(func(_, v int) int {
+		assert.ErrorIs(t, list.Range(0, 1).Reverse(), ErrReentrantCall)
+		return v
+	})
+	assert.Nil(t, err)
+}
+
+func TestView(t *testing.T) {
+	list := New[int](0, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+
+	v, err := list.View(3, 6)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{3, 4, 5, 6}, v.Slice())
+	assert.Equal(t, 4, v.Size())
+	x, ok := v.At(1)
+	assert.True(t, ok)
+	assert.Equal(t, 4, x)
+	_, ok = v.At(4)
+	assert.False(t, ok)
+
+	// Writes go to the parent
+	assert.Nil(t, v.Replace(0, 30))
+	assert.Nil(t, v.Modify(func(i, v int) int { return v * 10 }))
+	assert.Equal(t, []int{0, 1, 2, 300, 40, 50, 60, 7, 8, 9}, slicesOf(list))
+	assert.Nil(t, v.Sort(func(a, b int) bool { return a < b }))
+	assert.Equal(t, []int{0, 1, 2, 40, 50, 60, 300, 7, 8, 9}, slicesOf(list))
+
+	// Bounds follow inserts and deletes inside the view (through the view or the parent)
+	assert.Nil(t, v.Insert(0, -1))
+	assert.Nil(t, v.Insert(5, -2)) // the end of the view
+	assert.ErrorIs(t, v.Insert(7, -3), ErrInvalidIndex)
+	assert.Nil(t, list.Insert(5, -4)) // inside the view
+	assert.Equal(t, []int{-1, 40, -4, 50, 60, 300, -2}, v.Slice())
+	d, err := v.DeleteAt(1)
+	assert.Nil(t, err)
+	assert.Equal(t, 40, d)
+	_, _ = list.DeleteAt(4) // -4
+	assert.Equal(t, []int{-1, 50, 60, 300, -2}, v.Slice())
+
+	// Changes outside the view and deleted neighbours don't change it
+	list.Append(10)
+	assert.Nil(t, list.Insert(0, -10))
+	_, _ = list.DeleteAt(3) // 2, neighbour before the view
+	_, _ = list.DeleteAt(8) // 7, neighbour after the view
+	assert.Equal(t, []int{-10, 0, 1, -1, 50, 60, 300, -2, 8, 9, 10}, slicesOf(list))
+	assert.Equal(t, []int{-1, 50, 60, 300, -2}, v.Slice())
+
+	var got []int
+	for i, x := range v.All() {
+		got = append(got, i, x)
+		if i == 1 {
+			break
+		}
+	}
+	assert.Equal(t, []int{0, -1, 1, 50}, got)
+	assert.Equal(t, 407, Sum(v.Values()))
+
+	// Emptied view keeps its place
+	for v.Size() > 0 {
+		_, _ = v.DeleteAt(0)
+	}
+	assert.Nil(t, v.Append(7, 7))
+	assert.Equal(t, []int{-10, 0, 1, 7, 7, 8, 9, 10}, slicesOf(list))
+
+	// Whole list view grows with the list
+	all, err := list.View(0, -1)
+	assert.Nil(t, err)
+	list.Append(11)
+	assert.Equal(t, 9, all.Size())
+
+	// Callbacks of the view follow the reentrancy rules
+	assert.Nil(t, all.Modify(func(i, x int) int {
+		assert.ErrorIs(t, all.Append(1), ErrReentrantCall)
+		return x
+	}))
+
+	_, err = list.View(5, 20)
+	assert.ErrorIs(t, err, ErrInvalidIndex)
+	_, err = list.View(-1, 2)
+	assert.ErrorIs(t, err, ErrInvalidIndex)
+
+	// Clear closes the views, Close unsubscribes
+	list.Clear()
+	assert.Equal(t, 0, v.Size())
+	assert.ErrorIs(t, v.Append(1), ErrViewClosed)
+	assert.ErrorIs(t, all.Sort(func(a, b int) bool { return a < b }), ErrViewClosed)
+	assert.Equal(t, 2, len(list.observers))
+	v.Close()
+	all.Close()
+	assert.Equal(t, 0, len(list.observers))
+
+	empty, err := New[int]().View(0, -1)
+	assert.Nil(t, err)
+	assert.Nil(t, empty.Append(1, 2))
+	assert.Equal(t, []int{1, 2}, empty.Slice())
+}
+
+func TestWhere(t *testing.T) {
+	even := func(x int) bool { return x%2 == 0 }
+
+	for _, opt := range [][]func(*WhereOptions){nil, {WithMembershipIndex()}} {
+		list := New[int](1, 2, 3, 4, 5, 6)
+		f := list.Where(even, opt...)
+
+		assert.Equal(t, 3, f.Size())
+		assert.Equal(t, []int{2, 4, 6}, f.Slice())
+		x, ok := f.At(1)
+		assert.True(t, ok)
+		assert.Equal(t, 4, x)
+		_, ok = f.At(3)
+		assert.False(t, ok)
+		assert.True(t, f.Contains(2, 6))
+		assert.False(t, f.Contains(3))
+		assert.False(t, f.Contains(2, 8))
+
+		// Changes of the parent are reflected
+		list.Append(8, 9)
+		assert.Nil(t, list.Insert(0, 0))
+		assert.Nil(t, list.Replace(1, 10)) // 1
+		_, _ = list.DeleteAt(2)            // 2
+		assert.Nil(t, list.Swap(0, 1))
+		assert.Equal(t, []int{10, 0, 4, 6, 8}, f.Slice())
+		assert.True(t, f.Contains(10, 0))
+		assert.False(t, f.Contains(2))
+
+		list.Modify(func(_ int, x int) int { return x + 1 })
+		assert.Equal(t, []int{4, 6, 10}, f.Slice())
+		list.Sort(func(a, b int) bool { return a > b })
+		assert.Equal(t, []int{10, 6, 4}, f.Slice())
+
+		var got []int
+		for i, x := range f.All() {
+			got = append(got, i, x)
+		}
+		assert.Equal(t, []int{0, 10, 1, 6, 2, 4}, got)
+		assert.Equal(t, 20, Sum(f.Values()))
+
+		list.Set(2, 2, 3)
+		assert.Equal(t, 2, f.Size())
+		assert.True(t, f.Contains(2))
+		_, _ = list.DeleteAt(0)
+		assert.True(t, f.Contains(2))
+		_, _ = list.DeleteAt(0)
+		assert.False(t, f.Contains(2))
+
+		list.Clear()
+		assert.Equal(t, 0, f.Size())
+		list.Append(12)
+		assert.Equal(t, []int{12}, f.Slice())
+
+		// 'pred' runs as a callback: it can read the list, but not change it
+		g := list.Where(func(x int) bool {
+			assert.ErrorIs(t, list.Replace(0, 1), ErrReentrantCall)
+			return list.Contains(x)
+		}, opt...)
+		assert.Equal(t, 1, g.Size())
+
+		f.Close()
+		g.Close()
+		assert.Equal(t, 0, len(list.observers))
+		assert.Equal(t, []int{12}, f.Slice())
+	}
+
+	// The index calls 'pred' only for new and changed elements
+	calls := 0
+	list := New[int](1, 2, 3)
+	f := list.Where(func(x int) bool { calls++; return x > 1 }, WithMembershipIndex())
+	defer f.Close()
+
+	assert.Equal(t, 2, f.Size())
+	assert.Equal(t, []int{2, 3}, f.Slice())
+	assert.Equal(t, 3, calls)
+	list.Append(4)
+	assert.Nil(t, list.Replace(0, 5))
+	assert.True(t, f.Contains(4, 5))
+	assert.Equal(t, 5, calls)
+}
+
+func TestKeyIndexes(t *testing.T) {
+	type user struct {
+		ID   int
+		City string
+	}
+
+	list := New[user](user{1, "Berlin"}, user{2, "Paris"}, user{3, "Berlin"})
+	byID, err := AddIndex(list, "id", func(u user) int { return u.ID })
+	assert.Nil(t, err)
+	assert.Equal(t, "id", byID.Name())
+	byCityIdx, err := AddMultiIndex(list, "city", func(u user) string { return u.City })
+	assert.Nil(t, err)
+	_, err = AddIndex(list, "id", func(u user) int { return u.ID })
+	assert.ErrorIs(t, err, ErrIndexExists)
+	_, err = AddIndex(list, "city1", func(u user) string { return u.City })
+	assert.ErrorIs(t, err, ErrDuplicateKey)
+
+	// elements come in list order
+	byCity := func(city string) []int {
+		users, err := byCityIdx.Lookup(city)
+		assert.Nil(t, err)
+		ids := make([]int, 0, len(users))
+		for _, u := range users {
+			ids = append(ids, u.ID)
+		}
+		return ids
+	}
+
+	u, err := byID.LookupOne(2)
+	assert.Nil(t, err)
+	assert.Equal(t, "Paris", u.City)
+	assert.Equal(t, []int{1, 3}, byCity("Berlin"))
+	_, err = byID.LookupOne(4)
+	assert.ErrorIs(t, err, ErrElementNotFound)
+
+	// Mutations keep the indexes up to date
+	list.Append(user{4, "Rome"})
+	assert.Nil(t, list.Insert(0, user{5, "Paris"}))
+	assert.Nil(t, list.Replace(1, user{1, "Rome"}))
+	_, _ = list.DeleteAt(2) // 2
+	assert.Equal(t, []int{3}, byCity("Berlin"))
+	assert.Equal(t, []int{1, 4}, byCity("Rome"))
+	assert.Equal(t, []int{5}, byCity("Paris"))
+	_, err = byID.LookupOne(2)
+	assert.ErrorIs(t, err, ErrElementNotFound)
+
+	list.Modify(func(_ int, u user) user { u.ID *= 10; return u })
+	u, err = byID.LookupOne(40)
+	assert.Nil(t, err)
+	assert.Equal(t, "Rome", u.City)
+	assert.Empty(t, byCity("Oslo"))
+
+	other := New[user](user{60, "Oslo"})
+	list.Splice(other)
+	assert.Equal(t, []int{60}, byCity("Oslo"))
+	assert.Nil(t, list.Insert(0, user{70, "Rome"}))
+	assert.Equal(t, []int{70, 10, 40}, byCity("Rome"))
+
+	// Later duplicates of a unique key fail lookups of that key until they are gone
+	list.Append(user{60, "Kyiv"})
+	_, err = byID.LookupOne(60)
+	assert.ErrorIs(t, err, ErrDuplicateKey)
+	_, err = byID.Lookup(60)
+	assert.ErrorIs(t, err, ErrDuplicateKey)
+	u, err = byID.LookupOne(10)
+	assert.Nil(t, err)
+	assert.Equal(t, "Rome", u.City)
+	assert.Equal(t, []int{60}, byCity("Kyiv"))
+	_, _ = list.DeleteLast()
+	_, err = byID.LookupOne(60)
+	assert.Nil(t, err)
+
+	// Elements of a key stay in list order after inserts, splices and value changes
+	assert.Nil(t, list.Insert(2, user{80, "Rome"}, user{90, "Rome"}))
+	assert.Nil(t, list.SpliceAtPos(1, New[user](user{100, "Rome"}, user{110, "Oslo"})))
+	assert.Equal(t, []int{70, 100, 80, 90, 10, 40}, byCity("Rome"))
+	assert.Equal(t, []int{110, 60}, byCity("Oslo"))
+	list.Sort(func(a, b user) bool { return a.ID > b.ID })
+	assert.Equal(t, []int{100, 90, 80, 70, 40, 10}, byCity("Rome"))
+	assert.Nil(t, list.Replace(1, user{100, "Oslo"}))
+	assert.Equal(t, []int{110, 100, 60}, byCity("Oslo"))
+	assert.Equal(t, []int{90, 80, 70, 40, 10}, byCity("Rome"))
+
+	// Inserts into the same place run out of room between order labels and relabel the index
+	var lima []int
+	for i := range 100 {
+		assert.Nil(t, list.Insert(1, user{1000 + i, "Lima"}))
+		lima = append([]int{1000 + i}, lima...)
+	}
+	assert.Equal(t, lima, byCity("Lima"))
+	assert.Equal(t, []int{90, 80, 70, 40, 10}, byCity("Rome"))
+
+	list.Clear()
+	assert.Empty(t, byCity("Rome"))
+	list.Set(user{7, "Rome"})
+	assert.Equal(t, []int{7}, byCity("Rome"))
+
+	// Key functions are callbacks of the list
+	check, err := AddIndex(list, "check", func(u user) int {
+		assert.ErrorIs(t, list.Insert(0, u), ErrReentrantCall)
+		return list.Size()
+	})
+	assert.Nil(t, err)
+	list.Append(user{8, "Rome"})
+	_, err = check.Lookup(2)
+	assert.Nil(t, err)
+
+	assert.Nil(t, list.DropIndex("check"))
+	assert.ErrorIs(t, list.DropIndex("check"), ErrIndexNotFound)
+	_, err = check.Lookup(2)
+	assert.ErrorIs(t, err, ErrIndexNotFound)
+	assert.Nil(t, list.DropIndex("id"))
+	assert.Nil(t, list.DropIndex("city"))
+	assert.Equal(t, 0, len(list.observers))
+}
+
+func TestUnique(t *testing.T) {
+	list := New[int](1, 2, 3)
+	list.AppendUnique(3, 4, 4, 5, 1)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, slicesOf(list))
+
+	list.AppendUniqueBy(func(x int) any { return x % 10 }, 11, 16, 26, 7)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 16, 7}, slicesOf(list))
+
+	list.AppendUniqueFunc(func(a, b int) bool { return a/10 == b/10 }, 12, 25, 27)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 16, 7, 25}, slicesOf(list))
+
+	// Unique keeps the first of equal elements
+	list = New[int](3, 1, 3, 2, 1, 3)
+	assert.Equal(t, 3, list.Unique())
+	assert.Equal(t, []int{3, 1, 2}, slicesOf(list))
+	assert.Equal(t, 0, list.Unique())
+
+	list.Append(10, 21, 12)
+	assert.Equal(t, 2, list.UniqueBy(func(x int) any { return x % 10 }))
+	assert.Equal(t, []int{3, 1, 2, 10}, slicesOf(list))
+
+	// Values that can't be marshalled are compared natively
+	ch1, ch2 := make(chan int), make(chan int)
+	chans := New[chan int](ch1)
+	chans.AppendUnique(ch1, ch2, ch2)
+	assert.Equal(t, 2, chans.Size())
+
+	// Hasher compares pointees
+	users := New[*hashedUser](&hashedUser{1, "a"}, &hashedUser{2, "b"})
+	users.AppendUnique(&hashedUser{1, "c"}, &hashedUser{3, "d"}, &hashedUser{3, "e"})
+	assert.Equal(t, 3, users.Size())
+	last, _ := users.LastObject()
+	assert.Equal(t, "d", last.Name)
+	users.Append(&hashedUser{2, "f"})
+	assert.Equal(t, 1, users.Unique())
+
+	// Callbacks follow the reentrancy rules
+	list.AppendUniqueBy(func(x int) any {
+		assert.Panics(t, func() { list.Unique() })
+		return x
+	}, 5)
+	assert.Equal(t, []int{3, 1, 2, 10, 5}, slicesOf(list))
+}
+
+func TestSetOps(t *testing.T) {
+	a := New[int](1, 2, 2, 3)
+	b := New[int](2, 4, 2, 2)
+
+	assert.Equal(t, []int{1, 2, 2, 3, 4, 2}, slicesOf(a.Union(b)))
+	assert.Equal(t, []int{2, 2}, slicesOf(a.Intersect(b)))
+	assert.Equal(t, []int{1, 3}, slicesOf(a.Difference(b)))
+	assert.Equal(t, []int{1, 3, 4, 2}, slicesOf(a.SymmetricDifference(b)))
+	assert.Equal(t, []int{4, 2}, slicesOf(b.Difference(a)))
+
+	distinct := WithDistinct()
+	assert.Equal(t, []int{1, 2, 3, 4}, slicesOf(a.Union(b, distinct)))
+	assert.Equal(t, []int{2}, slicesOf(a.Intersect(b, distinct)))
+	assert.Equal(t, []int{1, 3}, slicesOf(a.Difference(b, distinct)))
+	assert.Equal(t, []int{1, 3, 4}, slicesOf(a.SymmetricDifference(b, distinct)))
+
+	// Subsets
+	c := New[int](2, 2, 1)
+	assert.True(t, c.IsSubsetOf(a))
+	assert.True(t, a.IsSupersetOf(c))
+	assert.False(t, c.IsSubsetOf(b))
+	assert.True(t, c.IsSubsetOf(New[int](1, 2), distinct))
+	assert.False(t, New[int](1, 2).IsSupersetOf(c))
+	assert.True(t, New[int](1, 2).IsSupersetOf(c, distinct))
+	assert.True(t, New[int]().IsSubsetOf(nil))
+	assert.False(t, a.Disjoint(b))
+	assert.True(t, a.Disjoint(New[int](5, 6)))
+	assert.True(t, a.Disjoint(nil))
+
+	// Nil and same lists
+	assert.Equal(t, []int{1, 2, 2, 3}, slicesOf(a.Union(nil)))
+	assert.Empty(t, slicesOf(a.Intersect(nil)))
+	assert.Equal(t, []int{1, 2, 2, 3}, slicesOf(a.Union(a)))
+	assert.Empty(t, slicesOf(a.Difference(a)))
+	assert.True(t, a.IsSubsetOf(a))
+
+	// Key functions
+	mod10 := func(x int) any { return x % 10 }
+	x := New[int](1, 12, 23)
+	y := New[int](21, 2, 34)
+	assert.Equal(t, []int{1, 12, 23, 34}, slicesOf(x.UnionBy(y, mod10)))
+	assert.Equal(t, []int{1, 12}, slicesOf(x.IntersectBy(y, mod10)))
+	assert.Equal(t, []int{23}, slicesOf(x.DifferenceBy(y, mod10)))
+	assert.Equal(t, []int{23, 34}, slicesOf(x.SymmetricDifferenceBy(y, mod10)))
+	assert.True(t, New[int](11, 21).IsSubsetOfBy(x, mod10, distinct))
+	assert.False(t, New[int](11, 21).IsSubsetOfBy(x, mod10))
+	assert.True(t, x.IsSupersetOfBy(New[int](11), mod10))
+	assert.True(t, x.DisjointBy(New[int](5, 16), mod10))
+	assert.False(t, x.DisjointBy(y, mod10))
+
+	// Results keep the options of the receiver
+	unsync := NewWithOptions[int](WithUnsync())
+	unsync.Append(1, 2)
+	assert.True(t, unsync.Union(a).opts.unsync)
+
+	// Key functions are callbacks of both lists
+	_ = x.UnionBy(y, func(v int) any {
+		assert.Panics(t, func() { x.Append(1) })
+		assert.Panics(t, func() { y.Append(1) })
+		return y.Size()
+	})
+
+	// Opposite orders of the same pair don't deadlock
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 200 {
+				if i%2 == 0 {
+					_ = a.Intersect(b)
+					a.Append(i)
+				} else {
+					_ = b.Difference(a)
+					b.Append(i)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 4+800, a.Size())
+}
+
+func TestSearch(t *testing.T) {
+	list := New[int](5, 1, 4, 1, 5, 9, 2, 6)
+
+	index := func(i int, err error) int {
+		assert.Nil(t, err)
+		return i
+	}
+	assert.Equal(t, 1, index(list.IndexOf(1)))
+	assert.Equal(t, 3, index(list.IndexOf(1, WithPos(2))))
+	assert.Equal(t, -1, index(list.IndexOf(1, WithPos(4))))
+	assert.Equal(t, -1, index(list.IndexOf(7)))
+	assert.Equal(t, 4, index(list.LastIndexOf(5)))
+	assert.Equal(t, 0, index(list.LastIndexOf(5, WithPos(3))))
+	assert.Equal(t, -1, index(list.LastIndexOf(5, WithPos(3), WithEnd(0))))
+
+	even := func(x int) bool { return x%2 == 0 }
+	assert.Equal(t, 2, index(list.IndexFunc(even)))
+	assert.Equal(t, 6, index(list.IndexFunc(even, WithPos(3))))
+	assert.Equal(t, -1, index(list.IndexFunc(even, WithEnd(2))))
+	found, err := list.ContainsFunc(even)
+	assert.Nil(t, err)
+	assert.True(t, found)
+	found, _ = list.ContainsFunc(func(x int) bool { return x > 9 })
+	assert.False(t, found)
+
+	i, v, ok, err := list.FindFirst(func(_ int, x int) bool { return x > 4 }, WithPos(1))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 4, i)
+	assert.Equal(t, 5, v)
+	i, v, ok, _ = list.FindLast(func(_ int, x int) bool { return x < 5 })
+	assert.True(t, ok)
+	assert.Equal(t, 6, i)
+	assert.Equal(t, 2, v)
+	i, _, ok, _ = list.FindLast(func(_ int, x int) bool { return x > 10 })
+	assert.False(t, ok)
+	assert.Equal(t, -1, i)
+
+	indexes, err := list.FindAllIndexes(func(_ int, x int) bool { return x == 1 })
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 3}, indexes)
+	indexes, _ = list.FindAllIndexes(func(_ int, x int) bool { return even(x) }, WithStep(2))
+	assert.Equal(t, []int{2, 6}, indexes)
+	indexes, _ = list.FindAllIndexes(func(_ int, x int) bool { return x == 1 }, WithPos(4))
+	assert.Nil(t, indexes)
+
+	list.MarkAtIndex(5)
+	assert.Equal(t, 5, index(list.IndexFunc(func(int) bool { return true }, WithMarkedOnly())))
+
+	// ContainsFunc compares values behind pointers
+	type user struct{ ID int }
+	users := New[*user](&user{1}, &user{2})
+	assert.False(t, users.Contains(&user{2}))
+	found, _ = users.ContainsFunc(func(u *user) bool { return u.ID == 2 })
+	assert.True(t, found)
+
+	// The position right after the last element is "not found": the next search after a match at the end
+	last := New[int](1, 42)
+	assert.Equal(t, -1, index(last.IndexOf(42, WithPos(2))))
+	assert.Equal(t, -1, index(last.LastIndexOf(42, WithPos(2))))
+	assert.Equal(t, -1, index(New[int]().IndexOf(42, WithPos(0))))
+	_, _, ok, err = New[int]().FindFirst(func(int, int) bool { return true }, WithPos(0))
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	// Other invalid ranges are returned as error
+	i, err = list.IndexOf(1, WithPos(9))
+	assert.ErrorIs(t, err, ErrInvalidIndex)
+	assert.Equal(t, -1, i)
+	_, err = list.IndexOf(1, WithPos(8), WithStep(0))
+	assert.ErrorIs(t, err, ErrInvalidRange)
+	indexes, err = list.FindAllIndexes(func(int, int) bool { return true }, WithPos(-1))
+	assert.ErrorIs(t, err, ErrInvalidIndex)
+	assert.Nil(t, indexes)
+
+	// In snapshot mode callbacks may modify the list
+	i = index(list.IndexFunc(func(x int) bool {
+		list.Append(x)
+		return x == 9
+	}, WithSnapshot()))
+	assert.Equal(t, 5, i)
+	assert.Equal(t, 14, list.Size())
+}
+
+func TestSeqSearch(t *testing.T) {
+	list := New[int](1, 2, 1, 2, 1, 3, 1, 2, 1, 2, 1, 3)
+
+	assert.Equal(t, 2, list.IndexOfSeq(1, 2, 1, 3))
+	assert.Equal(t, 8, list.LastIndexOfSeq(1, 2, 1, 3))
+	assert.Equal(t, 0, list.IndexOfSeq(1, 2))
+	assert.Equal(t, 8, list.LastIndexOfSeq(1, 2))
+	assert.Equal(t, -1, list.IndexOfSeq(3, 3))
+	assert.Equal(t, -1, list.LastIndexOfSeq(2, 3))
+	assert.Equal(t, -1, list.IndexOfSeq(1, 2, 1, 3, 1, 2, 1, 2, 1, 3, 1, 2, 1))
+	assert.Equal(t, 0, list.IndexOfSeq())
+	assert.Equal(t, 12, list.LastIndexOfSeq())
+
+	// Occurrences don't overlap
+	assert.Equal(t, 2, list.CountSeq(1, 2, 1))
+	assert.Equal(t, 2, list.CountSeq(1, 3))
+	assert.Equal(t, 13, list.CountSeq())
+	assert.Equal(t, 2, New[int](1, 1, 1, 1, 1).CountSeq(1, 1))
+	assert.Equal(t, 0, New[int]().CountSeq(1))
+
+	// Split
+	parts := list.SplitOn(1, 3)
+	assert.Equal(t, 3, len(parts))
+	assert.Equal(t, []int{1, 2, 1, 2}, slicesOf(parts[0]))
+	assert.Equal(t, []int{1, 2, 1, 2}, slicesOf(parts[1]))
+	assert.Empty(t, slicesOf(parts[2]))
+	assert.Equal(t, 1, len(list.SplitOn(7)))
+	assert.Equal(t, 12, list.SplitOn(7)[0].Size())
+	assert.Equal(t, 12, len(list.SplitOn()))
+	assert.Equal(t, 1, len(New[int]().SplitOn(1)))
+
+	// Replace
+	assert.Equal(t, 2, list.ReplaceSeq([]int{1, 3}, []int{0}))
+	assert.Equal(t, []int{1, 2, 1, 2, 0, 1, 2, 1, 2, 0}, slicesOf(list))
+	assert.Equal(t, 4, list.ReplaceSeq([]int{1, 2}, []int{5, 6, 7}))
+	assert.Equal(t, []int{5, 6, 7, 5, 6, 7, 0, 5, 6, 7, 5, 6, 7, 0}, slicesOf(list))
+	assert.Equal(t, 2, list.ReplaceSeq([]int{7, 0}, nil))
+	assert.Equal(t, []int{5, 6, 7, 5, 6, 5, 6, 7, 5, 6}, slicesOf(list))
+	assert.Equal(t, 0, list.ReplaceSeq(nil, []int{1}))
+	assert.Equal(t, 10, list.Size())
+
+	// Custom equality
+	words := New[string]("GET", "/", "http", "get", "/", "HTTP")
+	eqFold := func(a, b string) bool { return strings.EqualFold(a, b) }
+	assert.Equal(t, 3, words.IndexOfSeq("get", "/"))
+	assert.Equal(t, 0, words.IndexOfSeqFunc(eqFold, "get", "/"))
+	assert.Equal(t, 3, words.LastIndexOfSeqFunc(eqFold, "get", "/"))
+	assert.Equal(t, 2, words.CountSeqFunc(eqFold, "/", "http"))
+	assert.Equal(t, 3, len(words.SplitOnFunc(eqFold, "/")))
+	assert.Equal(t, 2, words.ReplaceSeqFunc(eqFold, []string{"http"}, []string{"HTTP/1.1"}))
+	assert.Equal(t, []string{"GET", "/", "HTTP/1.1", "get", "/", "HTTP/1.1"}, slicesOf(words))
+
+	// Matches the naive search on random sequences
+	toString := func(values []int) string {
+		var b strings.Builder
+		for _, v := range values {
+			b.WriteByte(byte('a' + v))
+		}
+		return b.String()
+	}
+	for range 500 {
+		values := make([]int, rand.Intn(30))
+		for i := range values {
+			values[i] = rand.Intn(3)
+		}
+		pattern := make([]int, 1+rand.Intn(4))
+		for i := range pattern {
+			pattern[i] = rand.Intn(3)
+		}
+
+		l := New[int](values...)
+		s, sub := toString(values), toString(pattern)
+		assert.Equal(t, strings.Index(s, sub), l.IndexOfSeq(pattern...))
+		assert.Equal(t, strings.LastIndex(s, sub), l.LastIndexOfSeq(pattern...))
+		assert.Equal(t, strings.Count(s, sub), l.CountSeq(pattern...))
+		assert.Equal(t, len(strings.Split(s, sub)), len(l.SplitOn(pattern...)))
+		l.ReplaceSeq(pattern, []int{3})
+		assert.Equal(t, strings.ReplaceAll(s, sub, "d"), toString(slicesOf(l)))
+	}
+
+	// Callbacks follow the reentrancy rules
+	assert.Equal(t, 1, words.IndexOfSeqFunc(func(a, b string) bool {
+		assert.ErrorIs(t, words.Replace(0, ""), ErrReentrantCall)
+		return a == b
+	}, "/"))
+}